@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/router"
+)
+
+func (a *App) adminLifecycle(ctx *gin.Context) (router.Response, error) {
+	admin, err := a.clusterAdmin(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lc, err := a.admins.Lifecycle(ctx, admin.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get admin lifecycle")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, lc), nil
+}
+
+func (a *App) adminResetPassword(ctx *gin.Context) (router.Response, error) {
+	admin, err := a.clusterAdmin(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPassword, err := registry.GenerateAdminPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath := a.vaultPlatformPathKey(admin.Email)
+	if _, err := a.Services.Vault.Write(
+		vaultPath, map[string]interface{}{
+			a.Config.VaultClusterAdminsPasswordKey: tmpPassword,
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to write to vault")
+	}
+
+	if err := a.admins.ResetPassword(ctx, admin.Username, tmpPassword,
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to reset admin password")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, gin.H{"vaultPath": vaultPath}), nil
+}
+
+func (a *App) adminSetEnabled(ctx *gin.Context) (router.Response, error) {
+	admin, err := a.clusterAdmin(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.admins.SetEnabled(ctx, admin.Username, ctx.PostForm("enabled") == "true",
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to change admin state")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+func (a *App) adminSetExpiry(ctx *gin.Context) (router.Response, error) {
+	admin, err := a.clusterAdmin(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := registry.ParseAdminExpiry(ctx.PostForm("expiresAt"))
+	if err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	if err := a.admins.SetExpiry(ctx, admin.Username, expiresAt,
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to set admin expiry")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+// clusterAdmin checks user access to cluster and returns admin from cluster values by username
+func (a *App) clusterAdmin(ctx *gin.Context, modify bool) (*Admin, error) {
+	k8sService, err := a.Services.K8S.ServiceForContext(router.ContextWithUserAccessToken(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init k8s service for user")
+	}
+
+	verb := "get"
+	if modify {
+		verb = "update"
+	}
+
+	allowed, err := k8sService.CanI("v2.edp.epam.com", "codebases", verb, a.Config.CodebaseName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to check access to cluster codebase")
+	}
+
+	if !allowed {
+		return nil, errors.New("access denied")
+	}
+
+	username := ctx.Query("username")
+	if username == "" {
+		username = ctx.PostForm("username")
+	}
+
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode values yaml")
+	}
+
+	for i := range values.Admins {
+		if values.Admins[i].Username == username {
+			return &values.Admins[i], nil
+		}
+	}
+
+	return nil, errors.Wrapf(registry.ErrAdminNotFound, "%s is not cluster admin", username)
+}
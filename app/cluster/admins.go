@@ -51,7 +51,7 @@ func (a *App) updateAdmins(ctx *gin.Context) error {
 		return errors.Wrap(err, "unable to create admins secrets")
 	}
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return errors.Wrap(err, "unable to decode values yaml")
 	}
@@ -1,12 +1,15 @@
 package cluster
 
 import (
+	"ddm-admin-console/app/registry"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
 	edpComponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/jenkins"
 	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/service/keycloak"
 	"ddm-admin-console/service/vault"
 	"fmt"
 
@@ -31,8 +34,10 @@ type Services struct {
 	Jenkins      jenkins.ServiceInterface
 	K8S          k8s.ServiceInterface
 	Gerrit       gerrit.ServiceInterface
+	GitProvider  gitprovider.Provider
 	EDPComponent edpComponent.ServiceInterface
 	Vault        vault.ServiceInterface
+	Keycloak     keycloak.ServiceInterface
 }
 
 type Config struct {
@@ -48,6 +53,8 @@ type Config struct {
 	KeycloakDefaultHostname       string
 	DDMManualEDPComponent         string
 	RegistryDNSManualPath         string
+	UsersRealm                    string
+	UsersNamespace                string
 }
 
 type App struct {
@@ -56,6 +63,7 @@ type App struct {
 	router   router.Interface
 	repo     string
 	appCache *cache.Cache //TODO: change to interface
+	admins   *registry.Admins
 }
 
 func Make(router router.Interface, services Services, cnf Config, appCache *cache.Cache) (*App, error) {
@@ -65,6 +73,7 @@ func Make(router router.Interface, services Services, cnf Config, appCache *cach
 		router:   router,
 		repo:     fmt.Sprintf("%s/%s", cnf.RegistryRepoHost, cnf.ClusterRepo),
 		appCache: appCache,
+		admins:   registry.MakeAdmins(services.Keycloak, cnf.UsersRealm, cnf.UsersNamespace),
 	}
 
 	app.createRoutes()
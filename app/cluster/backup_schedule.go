@@ -104,7 +104,7 @@ func (a *App) backupSchedule(ctx *gin.Context) (router.Response, error) {
 		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"errors": errorsMap}), nil
 	}
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -30,7 +30,7 @@ func (a *App) updateCIDR(ctx *gin.Context) error {
 		return nil
 	}
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return errors.Wrap(err, "unable to get values contents")
 	}
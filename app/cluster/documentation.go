@@ -12,7 +12,7 @@ import (
 func (a *App) updateDemoRegistryName(ctx *gin.Context) (router.Response, error) {
 	demoRegistryNameValue := ctx.PostForm("demo-registry-name")
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values contents")
 	}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -15,7 +14,7 @@ import (
 	"ddm-admin-console/app/registry"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
-	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 )
 
 const (
@@ -82,7 +81,7 @@ func (a *App) editGet(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.Wrap(err, "unable to get ini template data")
 	}
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to load values")
 	}
@@ -176,8 +175,8 @@ func (a *App) loadCIDRConfig(_ context.Context, values *Values, rspParams gin.H)
 	return nil
 }
 
-func getValuesFromGit(projectName, branch string, gerritService gerrit.ServiceInterface) (*Values, error) {
-	content, err := gerritService.GetFileFromBranch(projectName, branch, url.PathEscape(ValuesLocation))
+func getValuesFromGit(projectName, branch string, provider gitprovider.Provider) (*Values, error) {
+	content, err := provider.GetFile(context.Background(), projectName, branch, ValuesLocation)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values yaml")
 	}
@@ -212,7 +211,7 @@ func (a *App) getUsedSignKeys(ctx *gin.Context) (map[string][]string, error) {
 
 	usedKeys := make(map[string][]string)
 	for _, reg := range cbs {
-		values, err := registry.GetValuesFromGit(strings.TrimLeft(*reg.Spec.GitUrlPath, "/"), registry.MasterBranch, a.Gerrit)
+		values, err := registry.GetValuesFromGit(strings.TrimLeft(*reg.Spec.GitUrlPath, "/"), registry.MasterBranch, a.GitProvider)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode values yaml, %w", err)
 		}
@@ -20,7 +20,7 @@ func (a *App) updateGeneral(ctx *gin.Context) (router.Response, error) {
 	faviconValue := ctx.PostForm("favicon")
 	languageValue := ctx.PostForm("language")
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values contents, %w", err)
 	}
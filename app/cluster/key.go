@@ -121,7 +121,7 @@ func (a *App) updateKey(ctx *gin.Context) error {
 		return err
 	}
 
-	values, err := registry.GetValuesFromGit(a.Config.CodebaseName, registry.MasterBranch, a.Gerrit)
+	values, err := registry.GetValuesFromGit(a.Config.CodebaseName, registry.MasterBranch, a.GitProvider)
 	if err != nil {
 		return errors.Wrap(err, "unable to get values from git")
 	}
@@ -147,7 +147,7 @@ func (a *App) updateKey(ctx *gin.Context) error {
 	}
 
 	if keysChanged || len(repoFiles) > 0 {
-		if err := registry.CreateEditMergeRequest(ctx, a.Config.CodebaseName, values.OriginalYaml, a.Gerrit, []string{}); err != nil {
+		if err := registry.CreateClusterEditMergeRequest(ctx, a.Config.CodebaseName, values.OriginalYaml, a.Gerrit, a.GitProvider, []string{}); err != nil {
 			return errors.Wrap(err, "unable to create edit merge request")
 		}
 	}
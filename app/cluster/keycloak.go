@@ -79,7 +79,7 @@ func (a *App) checkKeycloakHostnameUsed(ctx *gin.Context) (router.Response, erro
 	}
 
 	for _, cb := range cbs {
-		vals, err := registry.GetValuesFromGit(cb.Name, registry.MasterBranch, a.Gerrit)
+		vals, err := registry.GetValuesFromGit(cb.Name, registry.MasterBranch, a.GitProvider)
 		if err != nil {
 			return nil, fmt.Errorf("unable to get values for registry, %w", err)
 		}
@@ -103,7 +103,7 @@ func (a *App) keycloakDNS(ctx *gin.Context) (router.Response, error) {
 		return nil, fmt.Errorf("unable to decode hostnames, %w", err)
 	}
 
-	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.Gerrit)
+	values, err := getValuesFromGit(a.Config.CodebaseName, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load values, %w", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"ddm-admin-console/app/registry"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/valuesdoc"
 	"fmt"
 	"sort"
 	"time"
@@ -116,6 +117,41 @@ func (a *App) createValuesMergeRequestCtx(ctx *gin.Context, target, commitMessag
 }
 
 func (a *App) createValuesMergeRequest(ctx context.Context, cnf *valuesMrConfig) error {
+	values := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(cnf.values), &values); err != nil {
+		return errors.Wrap(err, "unable to decode new values")
+	}
+
+	current, err := a.GitProvider.GetFile(ctx, a.Config.CodebaseName, cnf.TargetBranch(), ValuesLocation)
+	if err != nil {
+		return errors.Wrap(err, "unable to get current values")
+	}
+
+	// cluster Values cover only part of values.yaml, so new values are merged into the file
+	doc, err := valuesdoc.Parse([]byte(current))
+	if err != nil {
+		return errors.Wrap(err, "unable to parse current values")
+	}
+
+	if err := doc.Merge(values); err != nil {
+		return errors.Wrap(err, "unable to merge new values")
+	}
+
+	merged := make(map[string]any)
+	if err := doc.Decode(&merged); err != nil {
+		return errors.Wrap(err, "unable to decode merged values")
+	}
+
+	contents, err := doc.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "unable to encode merged values")
+	}
+
+	if err := registry.ValidateProjectValues(a.GitProvider, a.Config.CodebaseName, cnf.TargetBranch(),
+		registry.ClusterValuesSchema, merged); err != nil {
+		return errors.Wrap(err, "values are not valid")
+	}
+
 	if err := a.Services.Gerrit.CreateMergeRequestWithContents(ctx, &gerrit.MergeRequest{
 		ProjectName:   a.Config.CodebaseName,
 		Name:          cnf.name,
@@ -127,7 +163,7 @@ func (a *App) createValuesMergeRequest(ctx context.Context, cnf *valuesMrConfig)
 			registry.MRLabelTarget: cnf.targetLabel,
 		},
 	}, map[string]string{
-		ValuesLocation: cnf.values,
+		ValuesLocation: string(contents),
 	}); err != nil {
 		return errors.Wrap(err, "unable to create MR with new values")
 	}
@@ -1,5 +1,7 @@
 package cluster
 
+import "ddm-admin-console/router"
+
 func (a *App) createRoutes() {
 	a.router.GET("/admin/cluster/management", a.view)
 
@@ -7,6 +9,10 @@ func (a *App) createRoutes() {
 	a.router.POST("/admin/cluster/edit", a.editPost)
 	a.router.POST("/admin/cluster/upgrade", a.clusterUpdate)
 	a.router.POST("/admin/cluster/admins", a.updateAdminsView)
+	a.router.GET("/admin/cluster/admin-lifecycle", a.adminLifecycle, router.SkipCSP)
+	a.router.POST("/admin/cluster/admin-reset-password", a.adminResetPassword)
+	a.router.POST("/admin/cluster/admin-enabled", a.adminSetEnabled)
+	a.router.POST("/admin/cluster/admin-expiry", a.adminSetExpiry)
 	a.router.POST("/admin/cluster/key", a.updateKeyView)
 	a.router.POST("/admin/cluster/cidr", a.updateCIDRView)
 	a.router.POST("/admin/cluster/demo-registry-name", a.updateDemoRegistryName)
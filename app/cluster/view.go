@@ -57,7 +57,7 @@ func (a *App) view(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.Wrap(err, "unable to list namespaced edp components")
 	}
 
-	clusterValues, err := getValuesFromGit(a.ClusterRepo, masterBranch, a.Gerrit)
+	clusterValues, err := getValuesFromGit(a.ClusterRepo, masterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get cluster values, %w", err)
 	}
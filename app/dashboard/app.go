@@ -4,12 +4,16 @@ import (
 	"context"
 	"ddm-admin-console/service/openshift"
 	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/roles"
+	"ddm-admin-console/service/session"
 	"net/http"
 
+	"ddm-admin-console/app/registry"
 	"ddm-admin-console/config"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
 	edpComponent "ddm-admin-console/service/edp_component"
+	"ddm-admin-console/service/gerrit"
 	"ddm-admin-console/service/k8s"
 
 	"golang.org/x/oauth2"
@@ -25,6 +29,7 @@ type Logger interface {
 type OAuth interface {
 	GetTokenClient(ctx context.Context, code string) (token *oauth2.Token, oauthClient *http.Client, err error)
 	AuthCodeURL() string
+	RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
 }
 
 type App struct {
@@ -36,6 +41,10 @@ type App struct {
 	codebaseService     codebase.ServiceInterface
 	openShiftService    openshift.ServiceInterface
 	permService         permissions.ServiceInterface
+	rolesService        roles.ServiceInterface
+	sessionService      session.ServiceInterface
+	gerritService       gerrit.ServiceInterface
+	runtime             *registry.Runtime
 	clusterCodebaseName string
 }
 
@@ -49,6 +58,10 @@ func Make(router router.Interface, oauth OAuth, services *config.Services, clust
 		clusterCodebaseName: clusterCodebaseName,
 		codebaseService:     services.Codebase,
 		permService:         services.PermService,
+		rolesService:        services.Roles,
+		sessionService:      services.Sessions,
+		gerritService:       services.Gerrit,
+		runtime:             services.Runtime,
 	}
 
 	app.createRoutes()
@@ -3,8 +3,6 @@ package dashboard
 import (
 	"context"
 	"ddm-admin-console/router"
-	"ddm-admin-console/service/codebase"
-	"ddm-admin-console/service/k8s"
 	"fmt"
 	"net/http"
 
@@ -38,7 +36,13 @@ func (a *App) auth(ctx *gin.Context) (response router.Response, retErr error) {
 		session.Set(router.UserEmailSessionKey, user.Metadata.Name)
 	}
 
-	if err := a.setRegistryPermissionsToSession(userCtx, session); err != nil {
+	consoleRoles, err := a.rolesService.UserRoles(userCtx, user.Groups)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve console roles")
+	}
+	session.Set(router.RolesSessionKey, consoleRoles)
+
+	if err := a.setRegistryPermissionsToSession(ctx, userCtx, session); err != nil {
 		return nil, errors.Wrap(err, "unable to set registry permissions to session")
 	}
 
@@ -46,16 +50,10 @@ func (a *App) auth(ctx *gin.Context) (response router.Response, retErr error) {
 		return nil, errors.Wrap(err, "unable to save session")
 	}
 
-	go func() {
-		if err := a.permService.LoadUserRegistries(ctx); err != nil {
-			a.logger.Error(err.Error())
-		}
-	}()
-
 	return router.MakeRedirectResponse(http.StatusFound, "/admin/registry/overview"), nil
 }
 
-func (a *App) setRegistryPermissionsToSession(userCtx context.Context, session sessions.Session) error {
+func (a *App) setRegistryPermissionsToSession(ctx *gin.Context, userCtx context.Context, session sessions.Session) error {
 	k8sService, err := a.k8sService.ServiceForContext(userCtx)
 	if err != nil {
 		return errors.Wrap(err, "unable to init k8s service for user")
@@ -67,7 +65,7 @@ func (a *App) setRegistryPermissionsToSession(userCtx context.Context, session s
 	}
 	session.Set(router.CanViewClusterManagementSessionKey, canGetClusterCodebase)
 
-	canListCodebases, err := a.hasAccessToRegistries(k8sService)
+	canListCodebases, err := a.permService.HasRegistryAccess(ctx)
 	if err != nil {
 		return errors.Wrap(err, "unable to check access to codebases list")
 	}
@@ -82,25 +80,6 @@ func (a *App) setRegistryPermissionsToSession(userCtx context.Context, session s
 	return nil
 }
 
-func (a *App) hasAccessToRegistries(k8sService k8s.ServiceInterface) (bool, error) {
-	cbs, err := a.codebaseService.GetAllByType(codebase.RegistryCodebaseType)
-	if err != nil {
-		return false, errors.Wrap(err, "")
-	}
-
-	for i := range cbs {
-		canGet, err := k8sService.CanI("v2.edp.epam.com", "codebases", "get", cbs[i].Name)
-		if err != nil {
-			return false, errors.Wrapf(err, "unable to check access for codebase: %s", cbs[i].Name)
-		}
-		if canGet {
-			return true, nil
-		}
-	}
-
-	return false, nil
-}
-
 func (a *App) logout(ctx *gin.Context) (router.Response, error) {
 	if err := a.permService.DeleteTokenContext(ctx); err != nil {
 		return nil, fmt.Errorf("unable to delete token: %w", err)
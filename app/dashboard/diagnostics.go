@@ -0,0 +1,338 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/roles"
+)
+
+const (
+	DecisionClusterManagement = "cluster-management"
+	DecisionCreateRegistry    = "create-registry"
+	DecisionViewRegistry      = "view-registry"
+	DecisionUpdateRegistry    = "update-registry"
+	DecisionDeleteRegistry    = "delete-registry"
+
+	codebaseGroup    = "v2.edp.epam.com"
+	codebaseResource = "codebases"
+
+	authenticatedGroup = "system:authenticated"
+)
+
+// AccessCheck is a single access review made by console, granted by lists namespace role bindings of user
+// which allow the action, access given by cluster role bindings is only visible in authorizer reason.
+// ConsoleAllowed is the registry decision console actually uses, it is evaluated from cached rules review
+// of current user and is not known for impersonated users
+type AccessCheck struct {
+	Decision        string   `json:"decision"`
+	Verb            string   `json:"verb"`
+	Resource        string   `json:"resource"`
+	Name            string   `json:"name"`
+	Allowed         bool     `json:"allowed"`
+	Reason          string   `json:"reason"`
+	EvaluationError string   `json:"evaluationError,omitempty"`
+	GrantedBy       []string `json:"grantedBy"`
+	ConsoleAllowed  *bool    `json:"consoleAllowed,omitempty"`
+	ConsoleSource   string   `json:"consoleSource,omitempty"`
+}
+
+// VersionDecision explains whether registry is managed by this console, registries which versions do not match
+// version filter are hidden from registries list regardless of access
+type VersionDecision struct {
+	Registry      string `json:"registry"`
+	Version       string `json:"version"`
+	StoredVersion string `json:"storedVersion"`
+	Filter        string `json:"filter"`
+	Matched       bool   `json:"matched"`
+}
+
+// AccessDiagnostics explains what console shows to user and why
+type AccessDiagnostics struct {
+	User                     string             `json:"user"`
+	Groups                   []string           `json:"groups"`
+	Impersonated             bool               `json:"impersonated"`
+	ConsoleRoles             []string           `json:"consoleRoles"`
+	ConsolePermissions       []roles.Permission `json:"consolePermissions"`
+	CanViewClusterManagement bool               `json:"canViewClusterManagement"`
+	CanViewRegistries        bool               `json:"canViewRegistries"`
+	CanCreateRegistries      bool               `json:"canCreateRegistries"`
+	VisibleRegistries        []string           `json:"visibleRegistries"`
+	Checks                   []AccessCheck      `json:"checks"`
+	Versions                 []VersionDecision  `json:"versions"`
+}
+
+type accessReviewer func(ctx context.Context, group, resource, verb, name string) (*k8s.AccessReview, error)
+
+// registryAccess is what console decides visibility of registries on, decisions are made from rules of current
+// user and are nil for impersonated users
+type registryAccess struct {
+	registries    []codebase.Codebase
+	versionFilter *registry.VersionFilter
+	decisions     map[string]permissions.RegistryDecision
+}
+
+func (a *App) accessDiagnosticsView(ctx *gin.Context) (router.Response, error) {
+	templateArgs, err := json.Marshal(gin.H{
+		"canDiagnoseUsers": roles.Allowed(ctx.GetStringSlice(router.RolesSessionKey), roles.PermissionDiagnoseUsers),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode template arguments")
+	}
+
+	return router.MakeHTMLResponse(http.StatusOK, "dashboard/access-diagnostics.html", gin.H{
+		"page":         "access-diagnostics",
+		"templateArgs": string(templateArgs),
+	}), nil
+}
+
+func (a *App) accessDiagnosticsReport(ctx *gin.Context) (router.Response, error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+
+	var (
+		user         = ctx.Query("user")
+		groups       = splitGroups(ctx.Query("groups"))
+		impersonated = user != ""
+		reviewer     accessReviewer
+	)
+
+	if impersonated {
+		if !roles.Allowed(ctx.GetStringSlice(router.RolesSessionKey), roles.PermissionDiagnoseUsers) {
+			return router.MakeJSONResponse(http.StatusForbidden,
+				gin.H{"error": "only platform administrators can diagnose other users"}), nil
+		}
+
+		// api server adds this group only to authenticated requests, not to reviews of other users
+		groups = append(groups, authenticatedGroup)
+		reviewer = func(ctx context.Context, group, resource, verb, name string) (*k8s.AccessReview, error) {
+			return a.k8sService.ReviewSubjectAccess(ctx, user, groups, group, resource, verb, name)
+		}
+	} else {
+		me, err := a.openShiftService.GetMe(userCtx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get open shift user")
+		}
+
+		user, groups = me.Metadata.Name, me.Groups
+
+		k8sService, err := a.k8sService.ServiceForContext(userCtx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to init k8s service for user")
+		}
+
+		reviewer = k8sService.ReviewSelfAccess
+	}
+
+	consoleRoles, err := a.rolesService.UserRoles(ctx, groups)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve console roles")
+	}
+
+	br, err := permissions.LoadBindingRules(ctx, a.k8sService)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load role bindings")
+	}
+
+	registries, err := a.codebaseService.GetAllByType(codebase.RegistryCodebaseType)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registries")
+	}
+
+	if err := registry.LoadRegistryVersions(userCtx, a.gerritService, registries); err != nil {
+		return nil, errors.Wrap(err, "unable to load registry versions")
+	}
+
+	access := registryAccess{registries: registries, versionFilter: a.runtime.Load().VersionFilter}
+
+	// rules review can only be made for current user, so console decisions of other users are not known
+	if !impersonated {
+		names := make([]string, 0, len(registries))
+		for i := range registries {
+			names = append(names, registries[i].Name)
+		}
+
+		if access.decisions, err = a.permService.ExplainRegistries(ctx, names); err != nil {
+			return nil, errors.Wrap(err, "unable to explain registry permissions")
+		}
+	}
+
+	report := AccessDiagnostics{
+		User:               user,
+		Groups:             groups,
+		Impersonated:       impersonated,
+		ConsoleRoles:       consoleRoles,
+		ConsolePermissions: roles.Permissions(consoleRoles),
+	}
+
+	// users diagnosing themselves see only registries they can get, names of others are not disclosed
+	if err := diagnoseAccess(ctx, &report, reviewer, br, a.clusterCodebaseName, access, impersonated); err != nil {
+		return nil, errors.Wrap(err, "unable to diagnose access")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, report), nil
+}
+
+// diagnoseAccess repeats access checks console makes on login and on registries list, checks of
+// registries which can not be viewed are reported only when reportHidden is set
+func diagnoseAccess(ctx context.Context, report *AccessDiagnostics, reviewer accessReviewer,
+	br *permissions.BindingRules, clusterCodebaseName string, access registryAccess, reportHidden bool) error {
+	review := func(verb, name string) (*k8s.AccessReview, error) {
+		review, err := reviewer(ctx, codebaseGroup, codebaseResource, verb, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to review %s %s, %w", verb, name, err)
+		}
+
+		return review, nil
+	}
+
+	record := func(decision, verb, name string, review *k8s.AccessReview) bool {
+		granted := make([]string, 0)
+		for _, rb := range br.GrantedBy(report.User, report.Groups, codebaseGroup, codebaseResource, verb, name) {
+			granted = append(granted, fmt.Sprintf("RoleBinding %s (%s %s)", rb.Name, rb.RoleRef.Kind, rb.RoleRef.Name))
+		}
+
+		consoleAllowed, consoleSource := consoleDecision(access.decisions, verb, name)
+		report.Checks = append(report.Checks, AccessCheck{
+			Decision:        decision,
+			Verb:            verb,
+			Resource:        codebaseResource,
+			Name:            name,
+			Allowed:         review.Allowed,
+			Reason:          review.Reason,
+			EvaluationError: review.EvaluationError,
+			GrantedBy:       granted,
+			ConsoleAllowed:  consoleAllowed,
+			ConsoleSource:   consoleSource,
+		})
+
+		// console uses its own decision where it is known
+		if consoleAllowed != nil {
+			return *consoleAllowed
+		}
+
+		return review.Allowed
+	}
+
+	check := func(decision, verb, name string) (bool, error) {
+		r, err := review(verb, name)
+		if err != nil {
+			return false, err
+		}
+
+		return record(decision, verb, name, r), nil
+	}
+
+	var err error
+	if report.CanViewClusterManagement, err = check(DecisionClusterManagement, "get", clusterCodebaseName); err != nil {
+		return err
+	}
+
+	if report.CanCreateRegistries, err = check(DecisionCreateRegistry, "create", "*"); err != nil {
+		return err
+	}
+
+	report.VisibleRegistries = make([]string, 0)
+	report.Versions = make([]VersionDecision, 0)
+
+	for i := range access.registries {
+		cb := &access.registries[i]
+		name := cb.Name
+
+		getReview, err := review("get", name)
+		if err != nil {
+			return err
+		}
+
+		if consoleGet, _ := consoleDecision(access.decisions, "get", name); !reportHidden && !getReview.Allowed &&
+			(consoleGet == nil || !*consoleGet) {
+			continue
+		}
+
+		canGet := record(DecisionViewRegistry, "get", name, getReview)
+
+		versionDecision := checkVersion(access.versionFilter, cb)
+		report.Versions = append(report.Versions, versionDecision)
+
+		// update and delete are checked only for visible registries, as on registries list
+		if !canGet || !versionDecision.Matched {
+			continue
+		}
+
+		report.VisibleRegistries = append(report.VisibleRegistries, name)
+
+		if _, err := check(DecisionUpdateRegistry, "update", name); err != nil {
+			return err
+		}
+
+		if _, err := check(DecisionDeleteRegistry, "delete", name); err != nil {
+			return err
+		}
+	}
+
+	report.CanViewRegistries = len(report.VisibleRegistries) > 0
+
+	return nil
+}
+
+// consoleDecision returns registry permission console uses for verb, nil is returned when it is not known
+func consoleDecision(decisions map[string]permissions.RegistryDecision, verb, name string) (*bool, string) {
+	d, ok := decisions[name]
+	if !ok {
+		return nil, ""
+	}
+
+	var allowed bool
+
+	switch verb {
+	case "get":
+		allowed = d.CanGet
+	case "update":
+		allowed = d.CanUpdate
+	case "delete":
+		allowed = d.CanDelete
+	default:
+		return nil, ""
+	}
+
+	return &allowed, d.Source
+}
+
+func checkVersion(versionFilter *registry.VersionFilter, cb *codebase.Codebase) VersionDecision {
+	d := VersionDecision{
+		Registry: cb.Name,
+		Filter:   versionFilter.String(),
+		Matched:  versionFilter.CheckCodebase(cb),
+	}
+
+	if cb.Version != nil {
+		d.Version = cb.Version.Original()
+	}
+
+	if stored, ok := cb.StoredVersion(); ok {
+		d.StoredVersion = stored.Original()
+	}
+
+	return d
+}
+
+func splitGroups(groups string) []string {
+	result := make([]string, 0)
+	for _, g := range strings.Split(groups, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,116 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/service/permissions"
+)
+
+func TestDiagnoseAccess(t *testing.T) {
+	t.Parallel()
+
+	br := permissions.BindingRules{
+		Bindings: []rbacv1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "editors"},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "registry-editor"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "editors"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "others"},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "registry-editor"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+			},
+		},
+		Rules: map[string]permissions.Rules{
+			"Role/registry-editor": {{APIGroups: []string{codebaseGroup}, Resources: []string{codebaseResource},
+				Verbs: []string{"get", "update"}, ResourceNames: []string{"reg-1"}}},
+		},
+	}
+
+	reviewer := func(_ context.Context, _, _, verb, name string) (*k8s.AccessReview, error) {
+		allowed := (name == "reg-1" || name == "reg-3") && (verb == "get" || verb == "update")
+		return &k8s.AccessReview{Allowed: allowed, Reason: "reviewed"}, nil
+	}
+
+	registryOfVersion := func(name, v string) codebase.Codebase {
+		return codebase.Codebase{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{codebase.VersionAnnotation: v}},
+			Version:    version.Must(version.NewVersion(v)),
+		}
+	}
+
+	versionFilter, err := registry.MakeVersionFilter(">=1.9.5")
+	require.NoError(t, err)
+
+	access := registryAccess{
+		registries: []codebase.Codebase{
+			registryOfVersion("reg-1", "1.9.5"),
+			registryOfVersion("reg-2", "1.9.5"),
+			registryOfVersion("reg-3", "1.9.3"),
+		},
+		versionFilter: versionFilter,
+	}
+
+	report := AccessDiagnostics{User: "alice", Groups: []string{"editors"}}
+	err = diagnoseAccess(context.Background(), &report, reviewer, &br, "cluster", access, true)
+	require.NoError(t, err)
+
+	require.False(t, report.CanViewClusterManagement)
+	require.False(t, report.CanCreateRegistries)
+	require.True(t, report.CanViewRegistries)
+	require.Equal(t, []string{"reg-1"}, report.VisibleRegistries)
+
+	// cluster, create, reg-1 get/update/delete, reg-2 get, reg-3 get
+	require.Len(t, report.Checks, 7)
+	require.Equal(t, DecisionUpdateRegistry, report.Checks[3].Decision)
+	require.True(t, report.Checks[3].Allowed)
+	require.Nil(t, report.Checks[3].ConsoleAllowed, "console decisions of impersonated users are not known")
+	require.Equal(t, []string{"RoleBinding editors (Role registry-editor)"}, report.Checks[3].GrantedBy)
+	require.Empty(t, report.Checks[4].GrantedBy)
+	require.Equal(t, "reg-2", report.Checks[5].Name)
+	require.False(t, report.Checks[5].Allowed)
+
+	// reg-3 can be viewed, but is hidden by version filter
+	require.Equal(t, []VersionDecision{
+		{Registry: "reg-1", Version: "1.9.5", StoredVersion: "1.9.5", Filter: ">=1.9.5", Matched: true},
+		{Registry: "reg-2", Version: "1.9.5", StoredVersion: "1.9.5", Filter: ">=1.9.5", Matched: true},
+		{Registry: "reg-3", Version: "1.9.3", StoredVersion: "1.9.3", Filter: ">=1.9.5", Matched: false},
+	}, report.Versions)
+
+	// console decided from rules review that reg-1 can not be updated, reg-3 rules review was incomplete
+	access.decisions = map[string]permissions.RegistryDecision{
+		"reg-1": {RegistryPermission: permissions.RegistryPermission{CanGet: true}, Source: permissions.SourceRulesReview},
+		"reg-2": {Source: permissions.SourceRulesReview},
+		"reg-3": {RegistryPermission: permissions.RegistryPermission{CanGet: true, CanUpdate: true},
+			Source: permissions.SourceAccessReview},
+	}
+
+	selfReport := AccessDiagnostics{User: "alice", Groups: []string{"editors"}}
+	err = diagnoseAccess(context.Background(), &selfReport, reviewer, &br, "cluster", access, false)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"reg-1"}, selfReport.VisibleRegistries)
+	// registries that can not be viewed are not disclosed
+	require.Len(t, selfReport.Checks, 6)
+	for _, c := range selfReport.Checks {
+		require.NotEqual(t, "reg-2", c.Name)
+	}
+
+	require.Len(t, selfReport.Versions, 2)
+	require.Equal(t, DecisionUpdateRegistry, selfReport.Checks[3].Decision)
+	require.True(t, selfReport.Checks[3].Allowed)
+	require.False(t, *selfReport.Checks[3].ConsoleAllowed)
+	require.Equal(t, permissions.SourceRulesReview, selfReport.Checks[3].ConsoleSource)
+	require.Equal(t, "reg-3", selfReport.Checks[5].Name)
+	require.Equal(t, permissions.SourceAccessReview, selfReport.Checks[5].ConsoleSource)
+}
@@ -1,7 +1,14 @@
 package dashboard
 
+import "ddm-admin-console/router"
+
 func (a *App) createRoutes() {
 	a.router.GET("/", a.main)
 	a.router.GET("/auth/callback", a.auth)
 	a.router.GET("/admin/logout", a.logout)
+	a.router.GET("/admin/access-diagnostics", a.accessDiagnosticsView)
+	a.router.GET("/admin/access-diagnostics/report", a.accessDiagnosticsReport, router.SkipCSP)
+	a.router.GET("/admin/sessions", a.sessionsView)
+	a.router.GET("/admin/sessions/list", a.sessionsList, router.SkipCSP)
+	a.router.POST("/admin/sessions/revoke/:key", a.revokeSession)
 }
@@ -0,0 +1,99 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/roles"
+	"ddm-admin-console/service/session"
+)
+
+// SessionItem is an active console session, current marks session of request
+type SessionItem struct {
+	session.Info
+	Current bool `json:"current"`
+}
+
+func (a *App) sessionsView(ctx *gin.Context) (router.Response, error) {
+	templateArgs, err := json.Marshal(gin.H{
+		"canManageSessions": canManageSessions(ctx),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode template arguments")
+	}
+
+	return router.MakeHTMLResponse(http.StatusOK, "dashboard/sessions.html", gin.H{
+		"page":         "sessions",
+		"templateArgs": string(templateArgs),
+	}), nil
+}
+
+func (a *App) sessionsList(ctx *gin.Context) (router.Response, error) {
+	items, err := a.visibleSessions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list sessions")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, items), nil
+}
+
+func (a *App) revokeSession(ctx *gin.Context) (router.Response, error) {
+	key := ctx.Param("key")
+
+	items, err := a.visibleSessions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list sessions")
+	}
+
+	found := false
+	for _, it := range items {
+		if it.Key == key {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return router.MakeJSONResponse(http.StatusNotFound, gin.H{"error": "session not found"}), nil
+	}
+
+	if err := a.sessionService.Revoke(ctx, key); err != nil {
+		return nil, errors.Wrap(err, "unable to revoke session")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+// visibleSessions returns all sessions to users who can manage sessions and only own sessions to others
+func (a *App) visibleSessions(ctx *gin.Context) ([]SessionItem, error) {
+	infos, err := a.sessionService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		currentKey = session.Key(sessions.Default(ctx).ID())
+		user       = ctx.GetString(router.UserEmailSessionKey)
+		manage     = canManageSessions(ctx)
+		items      = make([]SessionItem, 0, len(infos))
+	)
+
+	for _, inf := range infos {
+		if !manage && inf.User != user {
+			continue
+		}
+
+		items = append(items, SessionItem{Info: inf, Current: inf.Key == currentKey})
+	}
+
+	return items, nil
+}
+
+func canManageSessions(ctx *gin.Context) bool {
+	return roles.Allowed(ctx.GetStringSlice(router.RolesSessionKey), roles.PermissionManageSessions)
+}
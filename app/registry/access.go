@@ -0,0 +1,361 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/permissions"
+)
+
+const (
+	AccessLevelView  = "view"
+	AccessLevelEdit  = "edit"
+	AccessLevelOwner = "owner"
+
+	accessLabelRegistry = "console/registry-access"
+	accessLabelLevel    = "console/access-level"
+
+	codebaseAPIGroup = "v2.edp.epam.com"
+	codebaseResource = "codebases"
+)
+
+var (
+	accessLevels     = []string{AccessLevelView, AccessLevelEdit, AccessLevelOwner}
+	accessLevelVerbs = map[string][]string{
+		AccessLevelView:  {"get"},
+		AccessLevelEdit:  {"get", "update"},
+		AccessLevelOwner: {"get", "update", "delete"},
+	}
+)
+
+// AccessSubject is a user or a group with access to registry codebase, level is set only for access
+// granted by console, access given by other role bindings can not be revoked from console
+type AccessSubject struct {
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Verbs     []string `json:"verbs"`
+	Level     string   `json:"level,omitempty"`
+	Bindings  []string `json:"bindings"`
+}
+
+type accessChange struct {
+	Kind  string `form:"kind" binding:"required,oneof=User Group"`
+	Name  string `form:"name" binding:"required"`
+	Level string `form:"level"`
+}
+
+func (a *App) registryAccess(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if _, err := a.checkAccessManagement(ctx, registryName, false); err != nil {
+		return nil, err
+	}
+
+	subjects, err := a.loadRegistryAccess(ctx, registryName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load registry access")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, subjects), nil
+}
+
+func (a *App) grantRegistryAccess(ctx *gin.Context) (router.Response, error) {
+	return a.changeRegistryAccess(ctx, true)
+}
+
+func (a *App) revokeRegistryAccess(ctx *gin.Context) (router.Response, error) {
+	return a.changeRegistryAccess(ctx, false)
+}
+
+func (a *App) changeRegistryAccess(ctx *gin.Context, grant bool) (router.Response, error) {
+	registryName := ctx.Param("name")
+
+	var change accessChange
+	if err := ctx.ShouldBind(&change); err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	if !grant {
+		change.Level = ""
+	} else if _, ok := accessLevelVerbs[change.Level]; !ok {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity,
+			gin.H{"error": fmt.Sprintf("unknown access level: %s", change.Level)}), nil
+	}
+
+	bindings, err := a.Services.K8S.GetRoleBindings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get role bindings")
+	}
+
+	managed := managedAccessBindings(registryName, bindings)
+	subject := rbacv1.Subject{Kind: change.Kind, Name: change.Name, APIGroup: rbacv1.GroupName}
+
+	// users can not grant or revoke more than they are allowed to do themselves
+	needDelete := change.Level == AccessLevelOwner || subjectAccessLevel(managed, subject) == AccessLevelOwner
+
+	allowed, err := a.checkAccessManagement(ctx, registryName, needDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return router.MakeJSONResponse(http.StatusForbidden,
+			gin.H{"error": "access level exceeds your own permissions"}), nil
+	}
+
+	apply, remove := changeAccessBindings(registryName, managed, subject, change.Level)
+	if err := a.applyAccessBindings(ctx, registryName, apply, remove); err != nil {
+		return nil, errors.Wrap(err, "unable to change registry access")
+	}
+
+	a.Services.Perms.Invalidate()
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+// checkAccessManagement requires user to be able to update registry, delete permission which is needed
+// to manage owners is reported without failing the request
+func (a *App) checkAccessManagement(ctx *gin.Context, registryName string, needDelete bool) (bool, error) {
+	k8sService, err := a.Services.K8S.ServiceForContext(router.ContextWithUserAccessToken(ctx))
+	if err != nil {
+		return false, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	canGet, canUpdate, canDelete, err := codebase.CheckCodebasePermission(registryName, k8sService)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to check registry permissions")
+	}
+
+	if !canGet || !canUpdate {
+		return false, errors.New("access denied")
+	}
+
+	return !needDelete || canDelete, nil
+}
+
+func (a *App) loadRegistryAccess(ctx context.Context, registryName string) ([]AccessSubject, error) {
+	br, err := permissions.LoadBindingRules(ctx, a.Services.K8S)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load role bindings")
+	}
+
+	return registryAccessSubjects(registryName, br), nil
+}
+
+func (a *App) applyAccessBindings(ctx context.Context, registryName string, apply []rbacv1.RoleBinding,
+	remove []string) error {
+	for i := range apply {
+		level := apply[i].Labels[accessLabelLevel]
+		if err := a.Services.K8S.ApplyRole(ctx, accessRole(registryName, level)); err != nil {
+			return errors.Wrap(err, "unable to apply access role")
+		}
+
+		if err := a.Services.K8S.ApplyRoleBinding(ctx, &apply[i]); err != nil {
+			return errors.Wrap(err, "unable to apply access role binding")
+		}
+	}
+
+	for _, name := range remove {
+		if err := a.Services.K8S.DeleteRoleBinding(ctx, name); err != nil {
+			return errors.Wrap(err, "unable to delete access role binding")
+		}
+
+		if err := a.Services.K8S.DeleteRole(ctx, name); err != nil {
+			return errors.Wrap(err, "unable to delete access role")
+		}
+	}
+
+	return nil
+}
+
+// registryAccessSubjects evaluates role bindings and returns subjects which can get, update or delete registry codebase
+func registryAccessSubjects(registryName string, br *permissions.BindingRules) []AccessSubject {
+	subjects := make(map[string]*AccessSubject)
+
+	for _, b := range br.Bindings {
+		rules := br.RoleRules(b.RoleRef)
+
+		var verbs []string
+		for _, v := range accessLevelVerbs[AccessLevelOwner] {
+			if rules.Allows(codebaseAPIGroup, codebaseResource, v, registryName) {
+				verbs = append(verbs, v)
+			}
+		}
+
+		if len(verbs) == 0 {
+			continue
+		}
+
+		for _, s := range b.Subjects {
+			key := s.Kind + "/" + s.Namespace + "/" + s.Name
+			subj, ok := subjects[key]
+			if !ok {
+				subj = &AccessSubject{Kind: s.Kind, Name: s.Name, Namespace: s.Namespace}
+				subjects[key] = subj
+			}
+
+			subj.Bindings = append(subj.Bindings, b.Name)
+			subj.Verbs = mergeVerbs(subj.Verbs, verbs)
+
+			if b.Labels[accessLabelRegistry] == registryName {
+				subj.Level = b.Labels[accessLabelLevel]
+			}
+		}
+	}
+
+	result := make([]AccessSubject, 0, len(subjects))
+	for _, s := range subjects {
+		sort.Strings(s.Bindings)
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func managedAccessBindings(registryName string, bindings []rbacv1.RoleBinding) []rbacv1.RoleBinding {
+	var managed []rbacv1.RoleBinding
+	for _, b := range bindings {
+		if b.Labels[accessLabelRegistry] == registryName {
+			managed = append(managed, b)
+		}
+	}
+
+	return managed
+}
+
+func subjectAccessLevel(managed []rbacv1.RoleBinding, subject rbacv1.Subject) string {
+	for _, b := range managed {
+		if subjectIndex(b.Subjects, subject) >= 0 {
+			return b.Labels[accessLabelLevel]
+		}
+	}
+
+	return ""
+}
+
+// changeAccessBindings moves subject to binding of level, empty level revokes access granted by console,
+// bindings left without subjects are returned for removal
+func changeAccessBindings(registryName string, managed []rbacv1.RoleBinding, subject rbacv1.Subject,
+	level string) (apply []rbacv1.RoleBinding, remove []string) {
+	byLevel := make(map[string]rbacv1.RoleBinding)
+	for _, b := range managed {
+		byLevel[b.Labels[accessLabelLevel]] = *b.DeepCopy()
+	}
+
+	for _, l := range accessLevels {
+		b, ok := byLevel[l]
+		if !ok && l != level {
+			continue
+		}
+
+		if !ok {
+			b = accessRoleBinding(registryName, l)
+		}
+
+		idx := subjectIndex(b.Subjects, subject)
+		switch {
+		case l == level && idx < 0:
+			b.Subjects = append(b.Subjects, subject)
+		case l != level && idx >= 0:
+			b.Subjects = append(b.Subjects[:idx], b.Subjects[idx+1:]...)
+		default:
+			continue
+		}
+
+		if len(b.Subjects) == 0 {
+			remove = append(remove, b.Name)
+			continue
+		}
+
+		apply = append(apply, b)
+	}
+
+	return apply, remove
+}
+
+func accessObjectName(registryName, level string) string {
+	return fmt.Sprintf("registry-access-%s-%s", registryName, level)
+}
+
+func accessLabels(registryName, level string) map[string]string {
+	return map[string]string{
+		accessLabelRegistry: registryName,
+		accessLabelLevel:    level,
+	}
+}
+
+func accessRole(registryName, level string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   accessObjectName(registryName, level),
+			Labels: accessLabels(registryName, level),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{codebaseAPIGroup},
+				Resources:     []string{codebaseResource},
+				ResourceNames: []string{registryName},
+				Verbs:         accessLevelVerbs[level],
+			},
+		},
+	}
+}
+
+func accessRoleBinding(registryName, level string) rbacv1.RoleBinding {
+	return rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   accessObjectName(registryName, level),
+			Labels: accessLabels(registryName, level),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     accessObjectName(registryName, level),
+		},
+	}
+}
+
+func subjectIndex(subjects []rbacv1.Subject, subject rbacv1.Subject) int {
+	for i, s := range subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mergeVerbs(current, verbs []string) []string {
+	for _, v := range verbs {
+		found := false
+		for _, c := range current {
+			if c == v {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			current = append(current, v)
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/permissions"
+)
+
+func TestRegistryAccessSubjects(t *testing.T) {
+	t.Parallel()
+
+	managed := accessRoleBinding("reg", AccessLevelEdit)
+	managed.Subjects = []rbacv1.Subject{{Kind: "User", Name: "alice"}}
+
+	roleRules := map[string]permissions.Rules{
+		"Role/" + managed.RoleRef.Name: permissions.PolicyRules(accessRole("reg", AccessLevelEdit).Rules),
+		"ClusterRole/codebase-admin": {{APIGroups: []string{"*"}, Resources: []string{codebaseResource},
+			Verbs: []string{"*"}}},
+		"Role/other-registry": permissions.PolicyRules(accessRole("other", AccessLevelOwner).Rules),
+	}
+
+	bindings := []rbacv1.RoleBinding{
+		managed,
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "codebase-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "admins"}, {Kind: "User", Name: "alice"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "other-registry"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "bob"}},
+		},
+	}
+
+	require.Equal(t, []AccessSubject{
+		{Kind: "Group", Name: "admins", Verbs: []string{"get", "update", "delete"}, Bindings: []string{"admins"}},
+		{Kind: "User", Name: "alice", Verbs: []string{"get", "update", "delete"}, Level: AccessLevelEdit,
+			Bindings: []string{"admins", "registry-access-reg-edit"}},
+	}, registryAccessSubjects("reg", &permissions.BindingRules{Bindings: bindings, Rules: roleRules}))
+}
+
+func TestChangeAccessBindings(t *testing.T) {
+	t.Parallel()
+
+	alice := rbacv1.Subject{Kind: "User", Name: "alice", APIGroup: rbacv1.GroupName}
+	bob := rbacv1.Subject{Kind: "User", Name: "bob", APIGroup: rbacv1.GroupName}
+
+	apply, remove := changeAccessBindings("reg", nil, alice, AccessLevelView)
+	require.Empty(t, remove)
+	require.Len(t, apply, 1)
+	require.Equal(t, "registry-access-reg-view", apply[0].Name)
+	require.Equal(t, []rbacv1.Subject{alice}, apply[0].Subjects)
+
+	view := apply[0]
+	view.Subjects = append(view.Subjects, bob)
+
+	apply, remove = changeAccessBindings("reg", []rbacv1.RoleBinding{view}, alice, AccessLevelOwner)
+	require.Empty(t, remove)
+	require.Len(t, apply, 2)
+	require.Equal(t, []rbacv1.Subject{bob}, apply[0].Subjects)
+	require.Equal(t, "registry-access-reg-owner", apply[1].Name)
+	require.Equal(t, []rbacv1.Subject{alice}, apply[1].Subjects)
+	require.Len(t, view.Subjects, 2, "managed bindings must not be modified")
+
+	apply, remove = changeAccessBindings("reg", []rbacv1.RoleBinding{view}, bob, "")
+	require.Len(t, apply, 1)
+	require.Equal(t, []rbacv1.Subject{alice}, apply[0].Subjects)
+	require.Empty(t, remove)
+
+	view.Subjects = []rbacv1.Subject{bob}
+	apply, remove = changeAccessBindings("reg", []rbacv1.RoleBinding{view}, bob, "")
+	require.Empty(t, apply)
+	require.Equal(t, []string{"registry-access-reg-view"}, remove)
+}
@@ -0,0 +1,496 @@
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/service/keycloak"
+)
+
+const (
+	AdminExpiresAtAnnotation = "admin-lifecycle/expires-at"
+	AdminHistoryAnnotation   = "admin-lifecycle/history"
+
+	AdminActionResetPassword = "reset-password"
+	AdminActionDisable       = "disable"
+	AdminActionEnable        = "enable"
+	AdminActionSetExpiry     = "set-expiry"
+	AdminActionClearExpiry   = "clear-expiry"
+	AdminActionExpired       = "expired"
+
+	requiredActionUpdatePassword = "UPDATE_PASSWORD"
+	// only the latest entries are kept to stay well below annotation size limits
+	adminHistoryLimit = 50
+	adminExpiryLayout = "2006-01-02"
+
+	adminPasswordLength      = 20
+	adminPasswordVaultKey    = "password"
+	registryAdminGroupPrefix = "cp-registry-admin-"
+)
+
+// every class is used at least once to satisfy keycloak password policies
+var adminPasswordClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!@#$%^&*-_=+",
+}
+
+var ErrAdminNotFound = errors.New("admin not found")
+
+type AdminHistoryEntry struct {
+	Action  string    `json:"action"`
+	By      string    `json:"by"`
+	At      time.Time `json:"at"`
+	Details string    `json:"details,omitempty"`
+}
+
+type AdminLifecycle struct {
+	Username              string              `json:"username"`
+	Email                 string              `json:"email"`
+	Enabled               bool                `json:"enabled"`
+	PasswordResetRequired bool                `json:"passwordResetRequired"`
+	ExpiresAt             *time.Time          `json:"expiresAt,omitempty"`
+	History               []AdminHistoryEntry `json:"history"`
+}
+
+// GetRealmUser returns realm user of platform administrator by username
+func (a *Admins) GetRealmUser(ctx context.Context, username string) (*keycloak.KeycloakRealmUser, error) {
+	usrs, err := a.keycloakService.GetUsersByRealm(ctx, a.usersRealm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get users by realm")
+	}
+
+	for i := range usrs {
+		if usrs[i].Spec.Username == username {
+			return &usrs[i], nil
+		}
+	}
+
+	return nil, ErrAdminNotFound
+}
+
+func (a *Admins) Lifecycle(ctx context.Context, username string) (*AdminLifecycle, error) {
+	u, err := a.GetRealmUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeAdminLifecycle(u), nil
+}
+
+// ResetPassword sets new temporary password and forces admin to change it on next login
+func (a *Admins) ResetPassword(ctx context.Context, username, tmpPassword, by string) error {
+	u, err := a.GetRealmUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	u.Spec.Password = tmpPassword
+	u.Spec.RequiredUserActions = appendUnique(u.Spec.RequiredUserActions, requiredActionUpdatePassword)
+	addAdminHistory(u, AdminHistoryEntry{Action: AdminActionResetPassword, By: by, At: time.Now().UTC()})
+
+	return a.updateRealmUser(ctx, u)
+}
+
+func (a *Admins) SetEnabled(ctx context.Context, username string, enabled bool, by string) error {
+	u, err := a.GetRealmUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	u.Spec.Enabled = enabled
+	action := AdminActionDisable
+
+	if enabled {
+		action = AdminActionEnable
+		// otherwise the expiry check disables admin again right away
+		if expiresAt, ok := adminExpiresAt(u); ok && !expiresAt.After(now) {
+			delete(u.Annotations, AdminExpiresAtAnnotation)
+		}
+	}
+
+	addAdminHistory(u, AdminHistoryEntry{Action: action, By: by, At: now})
+
+	return a.updateRealmUser(ctx, u)
+}
+
+// SetExpiry schedules admin deactivation, nil expiresAt removes the schedule
+func (a *Admins) SetExpiry(ctx context.Context, username string, expiresAt *time.Time, by string) error {
+	u, err := a.GetRealmUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	entry := AdminHistoryEntry{Action: AdminActionClearExpiry, By: by, At: time.Now().UTC()}
+	if expiresAt == nil {
+		delete(u.Annotations, AdminExpiresAtAnnotation)
+	} else {
+		if u.Annotations == nil {
+			u.Annotations = make(map[string]string)
+		}
+
+		u.Annotations[AdminExpiresAtAnnotation] = expiresAt.UTC().Format(time.RFC3339)
+		entry.Action = AdminActionSetExpiry
+		entry.Details = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	addAdminHistory(u, entry)
+
+	return a.updateRealmUser(ctx, u)
+}
+
+// DisableExpired disables all enabled admins which expiry date has passed and returns their usernames
+func (a *Admins) DisableExpired(ctx context.Context, now time.Time) ([]string, error) {
+	usrs, err := a.keycloakService.GetUsersByRealm(ctx, a.usersRealm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get users by realm")
+	}
+
+	var disabled []string
+	for i := range usrs {
+		if !adminExpired(&usrs[i], now) {
+			continue
+		}
+
+		usrs[i].Spec.Enabled = false
+		addAdminHistory(&usrs[i], AdminHistoryEntry{Action: AdminActionExpired, By: "system", At: now.UTC()})
+
+		if err := a.updateRealmUser(ctx, &usrs[i]); err != nil {
+			return disabled, errors.Wrapf(err, "unable to disable admin %s", usrs[i].Spec.Username)
+		}
+
+		disabled = append(disabled, usrs[i].Spec.Username)
+	}
+
+	return disabled, nil
+}
+
+func (a *Admins) updateRealmUser(ctx context.Context, u *keycloak.KeycloakRealmUser) error {
+	if err := a.keycloakService.UpdateUser(ctx, u); err != nil {
+		return errors.Wrap(err, "unable to update realm user")
+	}
+
+	return nil
+}
+
+func (a *Admins) isRegistryAdmin(u *keycloak.KeycloakRealmUser, registryName string) bool {
+	for _, g := range u.Spec.Groups {
+		if g == userGroupRoleNameFromRegistry(registryName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// otherAdminScopes returns registries administered by user besides registryName, other is set when user
+// has groups or roles which are not registry admin ones, e.g. of cluster administrators
+func otherAdminScopes(u *keycloak.KeycloakRealmUser, registryName string) (registries []string, other bool) {
+	own := userGroupRoleNameFromRegistry(registryName)
+	seen := map[string]struct{}{own: {}}
+
+	for _, name := range append(append([]string{}, u.Spec.Groups...), u.Spec.Roles...) {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+
+		if reg := strings.TrimPrefix(name, registryAdminGroupPrefix); reg != name {
+			registries = append(registries, reg)
+			continue
+		}
+
+		other = true
+	}
+
+	return registries, other
+}
+
+// GenerateAdminPassword returns random temporary password of platform administrator
+func GenerateAdminPassword() (string, error) {
+	var all string
+	for _, c := range adminPasswordClasses {
+		all += c
+	}
+
+	password := make([]byte, 0, adminPasswordLength)
+	for i := 0; i < adminPasswordLength; i++ {
+		class := all
+		if i < len(adminPasswordClasses) {
+			class = adminPasswordClasses[i]
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(class))))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to generate password")
+		}
+
+		password = append(password, class[n.Int64()])
+	}
+
+	// classes go first, so password is shuffled to not expose their positions
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to generate password")
+		}
+
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+func makeAdminLifecycle(u *keycloak.KeycloakRealmUser) *AdminLifecycle {
+	al := AdminLifecycle{
+		Username: u.Spec.Username,
+		Email:    u.Spec.Email,
+		Enabled:  u.Spec.Enabled,
+		History:  adminHistory(u),
+	}
+
+	for _, ra := range u.Spec.RequiredUserActions {
+		if ra == requiredActionUpdatePassword {
+			al.PasswordResetRequired = true
+			break
+		}
+	}
+
+	if expiresAt, ok := adminExpiresAt(u); ok {
+		al.ExpiresAt = &expiresAt
+	}
+
+	return &al
+}
+
+func adminExpiresAt(u *keycloak.KeycloakRealmUser) (time.Time, bool) {
+	val, ok := u.Annotations[AdminExpiresAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func adminExpired(u *keycloak.KeycloakRealmUser, now time.Time) bool {
+	if !u.Spec.Enabled {
+		return false
+	}
+
+	expiresAt, ok := adminExpiresAt(u)
+
+	return ok && !expiresAt.After(now)
+}
+
+func adminHistory(u *keycloak.KeycloakRealmUser) []AdminHistoryEntry {
+	history := []AdminHistoryEntry{}
+	if val, ok := u.Annotations[AdminHistoryAnnotation]; ok {
+		// broken history should not block admin management, it is overwritten on next action
+		_ = json.Unmarshal([]byte(val), &history)
+	}
+
+	return history
+}
+
+func addAdminHistory(u *keycloak.KeycloakRealmUser, entry AdminHistoryEntry) {
+	history := append(adminHistory(u), entry)
+	if len(history) > adminHistoryLimit {
+		history = history[len(history)-adminHistoryLimit:]
+	}
+
+	bts, _ := json.Marshal(history)
+	if u.Annotations == nil {
+		u.Annotations = make(map[string]string)
+	}
+
+	u.Annotations[AdminHistoryAnnotation] = string(bts)
+}
+
+func appendUnique(in []string, val string) []string {
+	for _, v := range in {
+		if v == val {
+			return in
+		}
+	}
+
+	return append(in, val)
+}
+
+// ParseAdminExpiry parses expiry date from form, empty value means no expiry
+func ParseAdminExpiry(val string) (*time.Time, error) {
+	if val == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(adminExpiryLayout, val)
+	if err != nil {
+		return nil, errors.Wrap(err, "wrong expiry date format")
+	}
+
+	return &t, nil
+}
+
+func (a *App) adminLifecycle(ctx *gin.Context) (router.Response, error) {
+	u, err := a.registryAdminUser(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, makeAdminLifecycle(u)), nil
+}
+
+func (a *App) adminResetPassword(ctx *gin.Context) (router.Response, error) {
+	u, err := a.registryAdminUser(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPassword, err := GenerateAdminPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath := fmt.Sprintf("%s/%s", a.vaultRegistryPathKey(ctx.Param("name"), "administrators"), u.Spec.Email)
+	if _, err := a.Services.Vault.Write(vaultPath, map[string]interface{}{
+		adminPasswordVaultKey: tmpPassword,
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to write to vault")
+	}
+
+	if err := a.admins.ResetPassword(ctx, u.Spec.Username, tmpPassword,
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to reset admin password")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, gin.H{"vaultPath": vaultPath}), nil
+}
+
+func (a *App) adminSetEnabled(ctx *gin.Context) (router.Response, error) {
+	u, err := a.registryAdminUser(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.admins.SetEnabled(ctx, u.Spec.Username, ctx.PostForm("enabled") == "true",
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to change admin state")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+func (a *App) adminSetExpiry(ctx *gin.Context) (router.Response, error) {
+	u, err := a.registryAdminUser(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := ParseAdminExpiry(ctx.PostForm("expiresAt"))
+	if err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	if err := a.admins.SetExpiry(ctx, u.Spec.Username, expiresAt,
+		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+		return nil, errors.Wrap(err, "unable to set admin expiry")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+func (a *App) registryAdminUser(ctx *gin.Context, modify bool) (*keycloak.KeycloakRealmUser, error) {
+	registryName := ctx.Param("name")
+
+	k8sService, err := a.Services.K8S.ServiceForContext(router.ContextWithUserAccessToken(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	canGet, canUpdate, _, err := codebase.CheckCodebasePermission(registryName, k8sService)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to check registry permissions")
+	}
+
+	if !canGet || (modify && !canUpdate) {
+		return nil, errors.New("access denied")
+	}
+
+	username := ctx.Query("username")
+	if username == "" {
+		username = ctx.PostForm("username")
+	}
+
+	u, err := a.admins.GetRealmUser(ctx, username)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get admin")
+	}
+
+	if !a.admins.isRegistryAdmin(u, registryName) {
+		return nil, errors.Wrapf(ErrAdminNotFound, "%s is not admin of %s", username, registryName)
+	}
+
+	if modify {
+		if err := a.checkOtherAdminScopes(u, registryName, k8sService); err != nil {
+			return nil, err
+		}
+	}
+
+	return u, nil
+}
+
+// checkOtherAdminScopes refuses changes of realm users shared with other registries or cluster,
+// unless caller can update all of them or manages cluster
+func (a *App) checkOtherAdminScopes(u *keycloak.KeycloakRealmUser, registryName string,
+	k8sService k8s.ServiceInterface) error {
+	registries, other := otherAdminScopes(u, registryName)
+	if len(registries) == 0 && !other {
+		return nil
+	}
+
+	canManageCluster, err := k8sService.CanI("v2.edp.epam.com", "codebases", "update", a.Config.ClusterCodebaseName)
+	if err != nil {
+		return errors.Wrap(err, "unable to check cluster permissions")
+	}
+
+	if canManageCluster {
+		return nil
+	}
+
+	if other {
+		return errors.Errorf("access denied, %s is also cluster administrator", u.Spec.Username)
+	}
+
+	for _, reg := range registries {
+		_, canUpdate, _, err := codebase.CheckCodebasePermission(reg, k8sService)
+		if err != nil {
+			return errors.Wrap(err, "unable to check registry permissions")
+		}
+
+		if !canUpdate {
+			return errors.Errorf("access denied, %s is also administrator of %s", u.Spec.Username, reg)
+		}
+	}
+
+	return nil
+}
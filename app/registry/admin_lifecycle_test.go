@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakMock "ddm-admin-console/mocks/keycloak"
+	"ddm-admin-console/service/keycloak"
+)
+
+func TestAdmins_DisableExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	kc := keycloakMock.ServiceInterface{}
+	kc.On("GetUsersByRealm", mock.Anything, "openshift").Return([]keycloak.KeycloakRealmUser{
+		{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AdminExpiresAtAnnotation: now.Add(-time.Hour).Format(time.RFC3339)}},
+			Spec: keycloak.KeycloakRealmUserSpec{Username: "expired", Enabled: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AdminExpiresAtAnnotation: now.Add(time.Hour).Format(time.RFC3339)}},
+			Spec: keycloak.KeycloakRealmUserSpec{Username: "active", Enabled: true},
+		},
+		{Spec: keycloak.KeycloakRealmUserSpec{Username: "no-expiry", Enabled: true}},
+	}, nil)
+	kc.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *keycloak.KeycloakRealmUser) bool {
+		h := adminHistory(u)
+		return u.Spec.Username == "expired" && !u.Spec.Enabled && len(h) == 1 && h[0].Action == AdminActionExpired
+	})).Return(nil)
+
+	disabled, err := MakeAdmins(&kc, "openshift", "user-management").DisableExpired(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, []string{"expired"}, disabled)
+	kc.AssertExpectations(t)
+}
+
+func TestAddAdminHistory(t *testing.T) {
+	t.Parallel()
+
+	var u keycloak.KeycloakRealmUser
+	for i := 0; i < adminHistoryLimit+5; i++ {
+		addAdminHistory(&u, AdminHistoryEntry{Action: AdminActionDisable, By: "admin@example.com"})
+	}
+	addAdminHistory(&u, AdminHistoryEntry{Action: AdminActionResetPassword, By: "admin@example.com"})
+
+	h := adminHistory(&u)
+	require.Len(t, h, adminHistoryLimit)
+	require.Equal(t, AdminActionResetPassword, h[len(h)-1].Action)
+}
+
+func TestMakeAdminLifecycle(t *testing.T) {
+	t.Parallel()
+
+	lc := makeAdminLifecycle(&keycloak.KeycloakRealmUser{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			AdminExpiresAtAnnotation: "2022-12-01T00:00:00Z",
+			AdminHistoryAnnotation:   "broken",
+		}},
+		Spec: keycloak.KeycloakRealmUserSpec{Username: "adm", Enabled: true,
+			RequiredUserActions: []string{requiredActionUpdatePassword}},
+	})
+
+	require.True(t, lc.PasswordResetRequired)
+	require.NotNil(t, lc.ExpiresAt)
+	require.Equal(t, 2022, lc.ExpiresAt.Year())
+	require.Empty(t, lc.History)
+}
+
+func TestOtherAdminScopes(t *testing.T) {
+	t.Parallel()
+
+	registries, other := otherAdminScopes(&keycloak.KeycloakRealmUser{Spec: keycloak.KeycloakRealmUserSpec{
+		Groups: []string{"cp-registry-admin-reg-1", "cp-registry-admin-reg-2"},
+		Roles:  []string{"cp-registry-admin-reg-1", "cp-registry-admin-reg-2"},
+	}}, "reg-1")
+	require.Equal(t, []string{"reg-2"}, registries)
+	require.False(t, other)
+
+	registries, other = otherAdminScopes(&keycloak.KeycloakRealmUser{Spec: keycloak.KeycloakRealmUserSpec{
+		Groups: []string{"cp-registry-admin-reg-1", "cp-cluster-mgmt-admin"},
+	}}, "reg-1")
+	require.Empty(t, registries)
+	require.True(t, other, "groups which are not registry admin ones belong to cluster administrators")
+}
+
+func TestGenerateAdminPassword(t *testing.T) {
+	t.Parallel()
+
+	first, err := GenerateAdminPassword()
+	require.NoError(t, err)
+	require.Len(t, first, adminPasswordLength)
+
+	for _, class := range adminPasswordClasses {
+		require.True(t, strings.ContainsAny(first, class), "every character class is used")
+	}
+
+	second, err := GenerateAdminPassword()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+}
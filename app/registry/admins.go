@@ -98,6 +98,22 @@ func (a *Admins) SyncAdmins(ctx context.Context, registryName string, admins []A
 	return nil
 }
 
+// RemoveRegistry removes registry admin group and role from all realm users
+func (a *Admins) RemoveRegistry(ctx context.Context, registryName string) error {
+	usrs, err := a.keycloakService.GetUsersByRealm(ctx, a.usersRealm)
+	if err != nil {
+		return errors.Wrap(err, "unable to get users by realm")
+	}
+
+	for i := range usrs {
+		if err := a.adminRemoveFromGroupsAndRoles(ctx, registryName, &usrs[i]); err != nil {
+			return errors.Wrap(err, "unable to remove registry from realm user groups and roles")
+		}
+	}
+
+	return nil
+}
+
 func (a *Admins) adminCreate(ctx context.Context, registryName string, adm *Admin) error {
 	if err := a.keycloakService.CreateUser(ctx, &keycloak.KeycloakRealmUser{
 		ObjectMeta: metav1.ObjectMeta{
@@ -126,7 +142,7 @@ func (a *Admins) adminCreate(ctx context.Context, registryName string, adm *Admi
 }
 
 func userGroupRoleNameFromRegistry(registryName string) string {
-	return fmt.Sprintf("cp-registry-admin-%s", registryName)
+	return registryAdminGroupPrefix + registryName
 }
 
 func userK8SNameFromUsername(username string) string {
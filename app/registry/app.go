@@ -1,13 +1,13 @@
 package registry
 
 import (
-	"fmt"
-	"strings"
+	"time"
 
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
 	edpComponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/jenkins"
 	"ddm-admin-console/service/k8s"
 	"ddm-admin-console/service/keycloak"
@@ -26,9 +26,8 @@ type Config struct {
 	EnableBranchProvisioners        bool
 	ClusterCodebaseName             string
 	ClusterRepo                     string
-	RegistryCodebaseLabels          string
-	Timezone                        string
 	UsersRealm                      string
+	OfficersRealm                   string
 	UsersNamespace                  string
 	VaultRegistrySecretPathTemplate string
 	VaultRegistrySMTPPwdSecretKey   string
@@ -38,21 +37,21 @@ type Config struct {
 	TempFolder                      string
 	RegistryDNSManualPath           string
 	DDMManualEDPComponent           string
-	RegistryVersionFilter           string
 	KeycloakDefaultHostname         string
 	WiremockAddr                    string
 	BackupBucketAccessKeyID         string
 	BackupBucketSecretAccessKey     string
 	RegistryTemplateName            string
+	GitServerName                   string
 	CloudProvider                   string
-	CurrentVersion                  string
-	PreviousVersion                 string
 	Region                          string
+	DeletionRetention               time.Duration
 }
 
 type Services struct {
 	Codebase     codebase.ServiceInterface
 	Gerrit       gerrit.ServiceInterface
+	GitProvider  gitprovider.Provider
 	EDPComponent edpComponent.ServiceInterface
 	K8S          k8s.ServiceInterface
 	Jenkins      jenkins.ServiceInterface
@@ -61,15 +60,14 @@ type Services struct {
 	Cache        *cache.Cache // TODO: replace with interface
 	Perms        permissions.ServiceInterface
 	OpenShift    openshift.ServiceInterface
+	Runtime      *Runtime
 }
 
 type App struct {
 	Config
 	Services
-	router         router.Interface
-	codebaseLabels map[string]string
-	admins         *Admins
-	versionFilter  *VersionFilter
+	router router.Interface
+	admins *Admins
 }
 
 func Make(router router.Interface, services Services, cnf Config) (*App, error) {
@@ -80,29 +78,10 @@ func Make(router router.Interface, services Services, cnf Config) (*App, error)
 		admins:   MakeAdmins(services.Keycloak, cnf.UsersRealm, cnf.UsersNamespace),
 	}
 
-	if cnf.RegistryCodebaseLabels != "" {
-		labels := strings.Split(cnf.RegistryCodebaseLabels, ",")
-		if len(labels) > 0 {
-			app.codebaseLabels = make(map[string]string)
-			for _, l := range labels {
-				labelParts := strings.Split(l, "=")
-				if len(labelParts) == 2 {
-					app.codebaseLabels[labelParts[0]] = labelParts[1]
-				}
-			}
-		}
-	}
-
 	app.createRoutes()
 	if err := app.registerCustomValidators(); err != nil {
 		return nil, errors.Wrap(err, "unable to register validators")
 	}
 
-	vf, err := MakeVersionFilter(cnf.RegistryVersionFilter)
-	if err != nil {
-		return nil, fmt.Errorf("unable to init version filter, %w", err)
-	}
-	app.versionFilter = vf
-
 	return app, nil
 }
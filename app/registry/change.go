@@ -4,37 +4,28 @@ import (
 	"context"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
 
-	goGerrit "github.com/andygrunwald/go-gerrit"
 	"github.com/gin-gonic/gin"
-)
-
-const (
-	currentRevision    = "current"
-	mergeList          = "/MERGE_LIST"
-	updateMRRetryCount = 5
+	"k8s.io/client-go/util/retry"
 )
 
 func (a *App) abandonChange(ctx *gin.Context) (response router.Response, retErr error) {
 	changeID := ctx.Param("change")
 
-	if _, _, err := a.Gerrit.GoGerritClient().Changes.AbandonChange(changeID, &goGerrit.AbandonInput{
-		Message: fmt.Sprintf("Abandoned by %s [%s]", ctx.GetString(router.UserNameSessionKey),
-			ctx.GetString(router.UserEmailSessionKey)),
-	}); err != nil {
+	if err := a.GitProvider.AbandonChange(ctx, changeID, changeAuthor(ctx)); err != nil {
 		return nil, fmt.Errorf("unable to abandon change, %w", err)
 	}
 
-	mr, err := a.updateMRStatus(ctx, changeID, "ABANDONED")
+	mr, err := a.updateMRStatus(ctx, changeID, gitprovider.StatusAbandoned)
 	if err != nil {
 		return nil, fmt.Errorf("unable to change MR status, %w", err)
 	}
@@ -49,12 +40,11 @@ func (a *App) abandonChange(ctx *gin.Context) (response router.Response, retErr
 func (a *App) submitChange(ctx *gin.Context) (response router.Response, retErr error) {
 	changeID := ctx.Param("change")
 
-	if err := a.Gerrit.ApproveAndSubmitChange(changeID, ctx.GetString(router.UserNameSessionKey),
-		ctx.GetString(router.UserEmailSessionKey)); err != nil {
+	if err := a.GitProvider.ApproveAndSubmitChange(ctx, changeID, changeAuthor(ctx)); err != nil {
 		return nil, fmt.Errorf("unable to approve change, %w", err)
 	}
 
-	if _, err := a.updateMRStatus(ctx, changeID, "MERGED"); err != nil {
+	if _, err := a.updateMRStatus(ctx, changeID, gitprovider.StatusMerged); err != nil {
 		return nil, fmt.Errorf("unable to change MR status, %w", err)
 	}
 
@@ -64,24 +54,19 @@ func (a *App) submitChange(ctx *gin.Context) (response router.Response, retErr e
 func (a *App) updateMRStatus(ctx context.Context, changeID, status string) (*gerrit.GerritMergeRequest, error) {
 	var mr *gerrit.GerritMergeRequest
 
-	for i := 0; i < updateMRRetryCount; i++ {
+	// status is updated by controller too, so update is retried on conflict with fresh object
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		var err error
 		mr, err = a.Gerrit.GetMergeRequestByChangeID(ctx, changeID)
 		if err != nil {
-			return nil, fmt.Errorf("unable to get MR, %w", err)
+			return fmt.Errorf("unable to get MR, %w", err)
 		}
 
 		mr.Status.Value = status
 
-		if err := a.Gerrit.UpdateMergeRequestStatus(ctx, mr); err != nil {
-			if strings.Contains(err.Error(), "modified") {
-				continue
-			}
-
-			return nil, fmt.Errorf("unable to update MR status, %w", err)
-		}
-
-		break
+		return a.Gerrit.UpdateMergeRequestStatus(ctx, mr)
+	}); err != nil {
+		return nil, fmt.Errorf("unable to update MR status, %w", err)
 	}
 
 	return mr, nil
@@ -90,19 +75,19 @@ func (a *App) updateMRStatus(ctx context.Context, changeID, status string) (*ger
 func (a *App) viewChange(ctx *gin.Context) (response router.Response, retErr error) {
 	changeID := ctx.Param("change")
 
-	changeInfo, _, err := a.Gerrit.GoGerritClient().Changes.GetChangeDetail(changeID, &goGerrit.ChangeOptions{})
+	change, err := a.GitProvider.GetChange(ctx, changeID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get gerrit change details, %w", err)
+		return nil, fmt.Errorf("unable to get change details, %w", err)
 	}
 
-	changes, err := a.getChangeContents(ctx, changeInfo)
+	changes, err := a.getChangeContents(ctx, changeID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get changes, %w", err)
 	}
 
 	rspParams := gin.H{
 		"changes":  changes,
-		"change":   changeInfo,
+		"change":   change,
 		"changeID": changeID,
 	}
 
@@ -117,21 +102,22 @@ func (a *App) viewChange(ctx *gin.Context) (response router.Response, retErr err
 	}), nil
 }
 
-func (a *App) getChangeContents(ctx context.Context, changeInfo *goGerrit.ChangeInfo) (string, error) {
-	files, _, err := a.Gerrit.GoGerritClient().Changes.ListFiles(changeInfo.ID, currentRevision, &goGerrit.FilesOptions{
-		Parent: 1,
-	})
+func changeAuthor(ctx *gin.Context) gitprovider.Author {
+	return gitprovider.Author{
+		Name:  ctx.GetString(router.UserNameSessionKey),
+		Email: ctx.GetString(router.UserEmailSessionKey),
+	}
+}
+
+func (a *App) getChangeContents(ctx context.Context, changeID string) (string, error) {
+	files, err := a.GitProvider.GetChangeFiles(ctx, changeID)
 	if err != nil {
 		return "", fmt.Errorf("unable to get change files, %w", err)
 	}
 
-	changes := make([]string, 0, len(files)-1)
-	for fileName := range files {
-		if fileName == "/COMMIT_MSG" || fileName == mergeList {
-			continue
-		}
-
-		changesContent, err := a.getChangeFileChanges(changeInfo.ID, fileName, changeInfo.Project)
+	changes := make([]string, 0, len(files))
+	for _, f := range files {
+		changesContent, err := a.getChangeFileChanges(f)
 		if err != nil {
 			return "", fmt.Errorf("unable to get file changes, %w", err)
 		}
@@ -148,87 +134,46 @@ func (a *App) getChangeContents(ctx context.Context, changeInfo *goGerrit.Change
 	return string(bts), nil
 }
 
-func (a *App) getChangeContentData(changeID, fileName, projectName string) (string, error) {
-	content, newHttpRsp, err := a.Gerrit.GoGerritClient().Changes.GetContent(changeID, currentRevision, fileName)
-	if err != nil && newHttpRsp != nil && newHttpRsp.StatusCode != 404 {
-		return "", errors.New("unable to get file content")
+func (a *App) getChangeFileChanges(f gitprovider.ChangedFile) (string, error) {
+	originalFilePath := path.Join(a.Config.TempFolder, "original", f.Path)
+	if !f.Added {
+		if err := writeChangeFile(originalFilePath, f.Old); err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(originalFilePath)
 	}
-	if newHttpRsp == nil || content == nil {
-		return "", errors.New("empty response")
+
+	newFilePath := path.Join(a.Config.TempFolder, "new", f.Path)
+	if !f.Deleted {
+		if err := writeChangeFile(newFilePath, f.New); err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(newFilePath)
 	}
 
-	return *content, nil
+	return createDiff(f.Path, originalFilePath, f.Added, newFilePath, f.Deleted), nil
 }
 
-// TODO: split function
-func (a *App) getChangeFileChanges(changeID, fileName, projectName string) (string, error) {
-	commitInfo, _, err := a.Gerrit.GoGerritClient().Changes.GetCommit(changeID, currentRevision, &goGerrit.CommitOptions{})
-	if err != nil {
-		return "", fmt.Errorf("unable to get change commit, %w", err)
-	}
-	if len(commitInfo.Parents) == 0 {
-		return "", errors.New("no parent commit for change found")
-	}
-
-	originalContent, originalHttpRsp, err := a.Gerrit.GoGerritClient().Projects.GetCommitContent(projectName,
-		commitInfo.Parents[0].Commit, url.PathEscape(fileName))
-	if err != nil && originalHttpRsp != nil && originalHttpRsp.StatusCode != 404 {
-		return "", fmt.Errorf("unable to get file content, %w", err)
-	}
-	if originalHttpRsp == nil {
-		return "", errors.New("empty http response")
+func writeChangeFile(filePath, content string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		return fmt.Errorf("unable to create folder, %w", err)
 	}
-	originalFilePath := path.Join(a.Config.TempFolder, "original", fileName)
-
-	if originalHttpRsp.StatusCode != 404 {
-		originalFolder := filepath.Dir(originalFilePath)
-		if err := os.MkdirAll(originalFolder, 0777); err != nil {
-			return "", fmt.Errorf("unable to create folder, %w", err)
-		}
 
-		originalFile, err := os.Create(originalFilePath)
-		if err != nil {
-			return "", fmt.Errorf("unable to creaete file, %w", err)
-		}
-		if _, err := originalFile.WriteString(originalContent); err != nil {
-			return "", fmt.Errorf("unable to write string, %w", err)
-		}
-		if err := originalFile.Close(); err != nil {
-			return "", fmt.Errorf("unable to close file, %w", err)
-		}
-		defer os.RemoveAll(originalFilePath)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to create file, %w", err)
 	}
 
-	content, newHttpRsp, err := a.Gerrit.GoGerritClient().Changes.GetContent(changeID, currentRevision, fileName)
-	if err != nil && newHttpRsp != nil && newHttpRsp.StatusCode != 404 {
-		return "", fmt.Errorf("unable to get file content, %w", err)
-	}
-	if newHttpRsp == nil {
-		return "", errors.New("empty response")
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to write string, %w", err)
 	}
-	newFilePath := path.Join(a.Config.TempFolder, "new", fileName)
 
-	if newHttpRsp.StatusCode != 404 && content != nil {
-		newFolder := filepath.Dir(newFilePath)
-		if err := os.MkdirAll(newFolder, 0777); err != nil {
-			return "", fmt.Errorf("unable to create folder, %w", err)
-		}
-
-		newFile, err := os.Create(newFilePath)
-		if err != nil {
-			return "", fmt.Errorf("unable to create file, %w", err)
-		}
-		if _, err := newFile.WriteString(*content); err != nil {
-			return "", fmt.Errorf("unable to write string, %w", err)
-		}
-		if err := newFile.Close(); err != nil {
-			return "", fmt.Errorf("unable to close file, %w", err)
-		}
-		defer os.RemoveAll(newFilePath)
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to close file, %w", err)
 	}
 
-	return createDiff(fileName, originalFilePath, originalHttpRsp.StatusCode == 404,
-		newFilePath, newHttpRsp.StatusCode == 404), nil
+	return nil
 }
 
 func createDiff(fileName, originalFilePath string, newFileAdded bool, newFilePath string, fileDeleted bool) string {
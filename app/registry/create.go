@@ -171,6 +171,7 @@ func (a *App) createRegistryGet(ctx *gin.Context) (response router.Response, ret
 		"platformStatusType":   a.Config.CloudProvider,
 		"registryVersion":      ctx.Request.URL.Query().Get("version"),
 		"clusterValues":        clusterValues,
+		"valuesErrors":         ctx.GetStringSlice(valuesErrorsKey),
 	}
 
 	templateArgs, err := json.Marshal(responseParams)
@@ -300,6 +301,11 @@ func (a *App) createRegistryPost(ctx *gin.Context) (response router.Response, re
 	}
 
 	if err := a.createRegistry(userCtx, ctx, &r, cbService); err != nil {
+		if messages := ValuesSchemaMessages(err); len(messages) > 0 {
+			ctx.Set(valuesErrorsKey, messages)
+			return a.createRegistryGet(ctx)
+		}
+
 		return nil, errors.Wrap(err, "unable to create registry")
 	}
 
@@ -332,7 +338,7 @@ func (a *App) createRegistry(
 		return errors.Wrap(err, "unknown error")
 	}
 
-	registryTemplate, err := GetValuesFromGit(a.Config.RegistryTemplateName, newRegistry.RegistryGitBranch, a.Gerrit)
+	registryTemplate, err := GetValuesFromGit(a.Config.RegistryTemplateName, newRegistry.RegistryGitBranch, a.GitProvider)
 	if err != nil {
 		return errors.Wrap(err, "unable to load registryTemplate from template")
 	}
@@ -346,6 +352,11 @@ func (a *App) createRegistry(
 		}
 	}
 
+	if err := ValidateProjectValues(a.GitProvider, a.Config.RegistryTemplateName, newRegistry.RegistryGitBranch,
+		RegistryValuesSchema, registryTemplate.OriginalYaml); err != nil {
+		return errors.Wrap(err, "values are not valid")
+	}
+
 	repoFiles := make(map[string]string)
 
 	if _, err := PrepareRegistryKeys(
@@ -440,9 +451,13 @@ func (a *App) encodeValues(registryName string, values map[string]interface{}) (
 }
 
 func (a *App) vaultRegistryPath(registryName string) string {
+	return VaultRegistryPath(a.Config.VaultRegistrySecretPathTemplate, a.Config.VaultKVEngineName, registryName)
+}
+
+func VaultRegistryPath(pathTemplate, engineName, registryName string) string {
 	return strings.ReplaceAll(
-		strings.ReplaceAll(a.Config.VaultRegistrySecretPathTemplate, "{registry}", registryName),
-		"{engine}", a.Config.VaultKVEngineName)
+		strings.ReplaceAll(pathTemplate, "{registry}", registryName),
+		"{engine}", engineName)
 }
 
 func (a *App) vaultRegistryPathKey(registryName, key string) string {
@@ -721,7 +736,7 @@ func (a *App) prepareRegistryCodebase(r *registry) *codebase.Codebase {
 			Framework:        &framework,
 			Strategy:         "import",
 			DeploymentScript: "openshift-template",
-			GitServer:        "gerrit",
+			GitServer:        a.Config.GitServerName,
 			GitUrlPath:       &gitURLPath,
 			CiTool:           "jenkins",
 			JobProvisioning:  &jobProvisioning,
@@ -753,8 +768,14 @@ func (a *App) prepareRegistryCodebase(r *registry) *codebase.Codebase {
 		}
 	}
 
-	if a.codebaseLabels != nil && len(a.codebaseLabels) > 0 {
-		cb.SetLabels(a.codebaseLabels)
+	// labels of runtime config are shared, so codebase gets its own copy
+	if labels := a.Runtime.Load().CodebaseLabels; len(labels) > 0 {
+		cbLabels := make(map[string]string, len(labels))
+		for k, v := range labels {
+			cbLabels[k] = v
+		}
+
+		cb.SetLabels(cbLabels)
 	}
 
 	return &cb
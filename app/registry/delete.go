@@ -2,7 +2,10 @@ package registry
 
 import (
 	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/k8s"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -10,18 +13,106 @@ import (
 
 func (a *App) deleteRegistry(ctx *gin.Context) (response router.Response, retErr error) {
 	userCtx := router.ContextWithUserAccessToken(ctx)
-	cbService, err := a.Services.Codebase.ServiceForContext(userCtx)
+	k8sService, err := a.Services.K8S.ServiceForContext(userCtx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init service for user context")
 	}
 
-	registryName := ctx.PostForm("registry-name")
-	err = cbService.Delete(registryName)
+	registryName := ctx.PostForm("registry-target")
+	if registryName == "" || registryName != ctx.PostForm("registry-name") {
+		return router.MakeStatusResponse(http.StatusUnprocessableEntity), nil
+	}
+
+	if err := a.checkDeleteAccess(registryName, k8sService); err != nil {
+		return nil, err
+	}
+
+	cb, err := a.Services.Codebase.Get(registryName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry")
+	}
+
+	if cb.PendingDeletion() {
+		return router.MakeRedirectResponse(http.StatusFound, "/admin/registry/overview"), nil
+	}
+
+	if cb.Annotations == nil {
+		cb.Annotations = make(map[string]string)
+	}
+
+	requestedBy := ctx.GetString(router.UserEmailSessionKey)
+
+	// request is recreated so that retention is counted from its fresh creation timestamp
+	if err := a.Services.K8S.DeleteSecret(ctx, DeletionRequestName(registryName)); err != nil {
+		return nil, errors.Wrap(err, "unable to remove previous deletion request")
+	}
+
+	if err := a.Services.K8S.ApplySecret(ctx, MakeDeletionRequest(registryName, requestedBy)); err != nil {
+		return nil, errors.Wrap(err, "unable to create deletion request")
+	}
+
+	cb.Annotations[codebase.DeletionRequestedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	cb.Annotations[codebase.DeletionRequestedByAnnotation] = requestedBy
+	delete(cb.Annotations, codebase.DeletionReportAnnotation)
+
+	if err := a.Services.Codebase.Update(userCtx, cb); err != nil {
+		if delErr := a.Services.K8S.DeleteSecret(ctx, DeletionRequestName(registryName)); delErr != nil {
+			return nil, errors.Wrapf(err, "unable to mark registry for deletion, deletion request is left: %s",
+				delErr.Error())
+		}
+
+		return nil, errors.Wrap(err, "unable to mark registry for deletion")
+	}
+
+	return router.MakeRedirectResponse(http.StatusFound, "/admin/registry/overview"), nil
+}
+
+func (a *App) restoreRegistry(ctx *gin.Context) (response router.Response, retErr error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+	k8sService, err := a.Services.K8S.ServiceForContext(userCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	registryName := ctx.Param("name")
+	if err := a.checkDeleteAccess(registryName, k8sService); err != nil {
+		return nil, err
+	}
+
+	cb, err := a.Services.Codebase.Get(registryName)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get registry")
 	}
 
-	a.Perms.DeleteRegistry(registryName)
+	if !cb.PendingDeletion() {
+		return router.MakeRedirectResponse(http.StatusFound, "/admin/registry/overview"), nil
+	}
+
+	// request is removed first, so a failed restore never leaves a stale request behind
+	if err := a.Services.K8S.DeleteSecret(ctx, DeletionRequestName(registryName)); err != nil {
+		return nil, errors.Wrap(err, "unable to remove deletion request")
+	}
+
+	delete(cb.Annotations, codebase.DeletionRequestedAtAnnotation)
+	delete(cb.Annotations, codebase.DeletionRequestedByAnnotation)
+	delete(cb.Annotations, codebase.DeletionReportAnnotation)
+
+	if err := a.Services.Codebase.Update(userCtx, cb); err != nil {
+		return nil, errors.Wrap(err, "unable to restore registry")
+	}
 
 	return router.MakeRedirectResponse(http.StatusFound, "/admin/registry/overview"), nil
 }
+
+func (a *App) checkDeleteAccess(registryName string, userK8sService k8s.ServiceInterface) error {
+	_, _, canDelete, err := codebase.CheckCodebasePermission(registryName, userK8sService)
+	if err != nil {
+		return errors.Wrap(err, "unable to check delete access")
+	}
+
+	if !canDelete {
+		return errors.New("access denied")
+	}
+
+	return nil
+}
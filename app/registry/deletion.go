@@ -0,0 +1,131 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/keycloak"
+	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/vault"
+)
+
+const (
+	DeletionStepVault       = "vault"
+	DeletionStepKeycloak    = "keycloak-admins"
+	DeletionStepCachedFiles = "cached-files"
+	DeletionStepPermissions = "permissions"
+
+	DeletionRequestLabel       = "registry-deletion-request"
+	deletionRequestedByDataKey = "requested-by"
+)
+
+// DeletionRequestName is a name of secret which confirms that deletion was requested through console,
+// only console service account writes it, so codebase annotations alone never trigger cleanup
+func DeletionRequestName(registryName string) string {
+	return "registry-deletion-request-" + registryName
+}
+
+func MakeDeletionRequest(registryName, requestedBy string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DeletionRequestName(registryName),
+			Labels: map[string]string{DeletionRequestLabel: registryName},
+		},
+		Data: map[string][]byte{
+			deletionRequestedByDataKey: []byte(requestedBy),
+		},
+	}
+}
+
+// DeletionRequestedBy returns user who requested deletion recorded in deletion request secret
+func DeletionRequestedBy(request *v1.Secret) string {
+	return string(request.Data[deletionRequestedByDataKey])
+}
+
+type DeletionStep struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+type DeletionReport struct {
+	Registry   string         `json:"registry"`
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt time.Time      `json:"finishedAt"`
+	Steps      []DeletionStep `json:"steps"`
+}
+
+func (r DeletionReport) Failed() bool {
+	for _, s := range r.Steps {
+		if s.Error != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Cleaner removes registry leftovers that are not owned by the codebase resource
+type Cleaner struct {
+	vault             vault.ServiceInterface
+	admins            *Admins
+	cache             *cache.Cache
+	perms             permissions.ServiceInterface
+	vaultPathTemplate string
+	vaultEngineName   string
+}
+
+func MakeCleaner(vaultService vault.ServiceInterface, keycloakService keycloak.ServiceInterface,
+	perms permissions.ServiceInterface, c *cache.Cache, cnf Config) *Cleaner {
+	return &Cleaner{
+		vault:             vaultService,
+		admins:            MakeAdmins(keycloakService, cnf.UsersRealm, cnf.UsersNamespace),
+		cache:             c,
+		perms:             perms,
+		vaultPathTemplate: cnf.VaultRegistrySecretPathTemplate,
+		vaultEngineName:   cnf.VaultKVEngineName,
+	}
+}
+
+// Cleanup runs every cleanup step, a failed step does not prevent the next ones from running
+func (c *Cleaner) Cleanup(ctx context.Context, registryName string) DeletionReport {
+	report := DeletionReport{
+		Registry:  registryName,
+		StartedAt: time.Now().UTC(),
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{name: DeletionStepVault, fn: func() error {
+			return c.vault.DeleteAll(VaultRegistryPath(c.vaultPathTemplate, c.vaultEngineName, registryName))
+		}},
+		{name: DeletionStepKeycloak, fn: func() error {
+			return c.admins.RemoveRegistry(ctx, registryName)
+		}},
+		{name: DeletionStepCachedFiles, fn: func() error {
+			return ClearRepoFiles(registryName, c.cache)
+		}},
+		{name: DeletionStepPermissions, fn: func() error {
+			c.perms.DeleteRegistry(registryName)
+			return nil
+		}},
+	}
+
+	for _, s := range steps {
+		step := DeletionStep{Name: s.name}
+		if err := s.fn(); err != nil {
+			step.Error = err.Error()
+		}
+
+		report.Steps = append(report.Steps, step)
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	return report
+}
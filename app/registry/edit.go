@@ -12,11 +12,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/hashicorp/go-version"
-	"gopkg.in/yaml.v3"
 
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/k8s"
 )
 
@@ -88,7 +88,7 @@ func (a *App) editRegistryGet(ctx *gin.Context) (response router.Response, retEr
 	if err != nil {
 		return nil, fmt.Errorf("unable to get template content, %w", err)
 	}
-	clusterValues, err := GetValuesFromGit(a.Config.ClusterRepo, MasterBranch, a.Services.Gerrit)
+	clusterValues, err := GetValuesFromGit(a.Config.ClusterRepo, MasterBranch, a.Services.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get cluster values, %w", err)
 	}
@@ -105,9 +105,10 @@ func (a *App) editRegistryGet(ctx *gin.Context) (response router.Response, retEr
 		"isPlatformAdmin":         ctx.GetBool(router.CanViewClusterManagementSessionKey),
 		"defaultRegistryValues":   valuesFromDefaultBranch,
 		"clusterDigitalSignature": clusterValues.DigitalSignature,
+		"valuesErrors":            ctx.GetStringSlice(valuesErrorsKey),
 	}
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values from git, %w", err)
 	}
@@ -392,6 +393,11 @@ func (a *App) editRegistryPost(ctx *gin.Context) (response router.Response, retE
 	}
 
 	if err := a.editRegistry(userCtx, ctx, &r, cb, cbService); err != nil {
+		if messages := ValuesSchemaMessages(err); len(messages) > 0 {
+			ctx.Set(valuesErrorsKey, messages)
+			return a.editRegistryGet(ctx)
+		}
+
 		return nil, fmt.Errorf("unable to edit registry, %w", err)
 	}
 
@@ -411,7 +417,7 @@ func (a *App) editRegistry(
 		cb.Annotations = make(map[string]string)
 	}
 
-	oldRegistry, err := GetValuesFromGit(updatedRegistry.Name, MasterBranch, a.Gerrit)
+	oldRegistry, err := GetValuesFromGit(updatedRegistry.Name, MasterBranch, a.GitProvider)
 	if err != nil {
 		return fmt.Errorf("unable to get oldRegistry from git, %w", err)
 	}
@@ -458,7 +464,8 @@ func (a *App) editRegistry(
 	}
 
 	if valuesChanged || len(repoFiles) > 0 || keysModified {
-		if err := CreateEditMergeRequest(ginContext, updatedRegistry.Name, oldRegistry.OriginalYaml, a.Gerrit, mrActions); err != nil {
+		if err := CreateEditMergeRequest(ginContext, updatedRegistry.Name, oldRegistry.OriginalYaml, a.Gerrit, a.GitProvider,
+			mrActions); err != nil {
 			return fmt.Errorf("unable to create edit merge request, %w", err)
 		}
 	}
@@ -481,54 +488,112 @@ type MRLabel struct {
 	Value string
 }
 
+// editMergeRequest describes merge request created with edited project values
+type editMergeRequest struct {
+	name          string
+	commitMessage string
+	labels        map[string]string
+	annotations   map[string]string
+}
+
+func makeEditMergeRequest(projectName string, mrActions []string, labels ...MRLabel) (*editMergeRequest, error) {
+	_labels := map[string]string{
+		MRLabelTarget: mrTargetEditRegistry,
+	}
+
+	for _, l := range labels {
+		_labels[l.Key] = l.Value
+	}
+
+	mrActionsJsonBts, err := json.Marshal(mrActions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode mr actions, %w", err)
+	}
+
+	return &editMergeRequest{
+		name:          fmt.Sprintf("reg-edit-mr-%s-%d", projectName, time.Now().Unix()),
+		commitMessage: "edit registry",
+		labels:        _labels,
+		annotations: map[string]string{
+			MRAnnotationActions: string(mrActionsJsonBts),
+		},
+	}, nil
+}
+
 func CreateEditMergeRequest(
 	ctx *gin.Context,
 	projectName string,
 	values map[string]any,
 	gerritService gerrit.ServiceInterface,
+	provider gitprovider.Provider,
 	mrActions []string,
 	labels ...MRLabel,
 ) error {
-	valuesYaml, err := yaml.Marshal(values)
+	mr, err := makeEditMergeRequest(projectName, mrActions, labels...)
 	if err != nil {
-		return fmt.Errorf("unable to encode values yaml, %w", err)
+		return err
 	}
 
-	mrExists, err := ProjectHasOpenMR(ctx, projectName, gerritService)
+	return createEditMergeRequest(ctx, ctx, projectName, RegistryValuesSchema, values, gerritService, provider, mr)
+}
+
+// CreateClusterEditMergeRequest creates edit merge request for cluster values validated against cluster schema
+func CreateClusterEditMergeRequest(
+	ctx *gin.Context,
+	projectName string,
+	values map[string]any,
+	gerritService gerrit.ServiceInterface,
+	provider gitprovider.Provider,
+	mrActions []string,
+	labels ...MRLabel,
+) error {
+	mr, err := makeEditMergeRequest(projectName, mrActions, labels...)
 	if err != nil {
-		return fmt.Errorf("unable to check project MR exists, %w", err)
+		return err
 	}
 
-	if mrExists {
-		return MRExists("there is already open merge request(s) for this registry")
-	}
+	return createEditMergeRequest(ctx, ctx, projectName, ClusterValuesSchema, values, gerritService, provider, mr)
+}
 
-	_labels := map[string]string{
-		MRLabelTarget: mrTargetEditRegistry,
+// createEditMergeRequest validates values and creates merge request with them on behalf of userCtx
+func createEditMergeRequest(
+	ctx *gin.Context,
+	userCtx context.Context,
+	projectName, valuesSchema string,
+	values map[string]any,
+	gerritService gerrit.ServiceInterface,
+	provider gitprovider.Provider,
+	mr *editMergeRequest,
+) error {
+	if err := ValidateProjectValues(provider, projectName, MasterBranch, valuesSchema, values); err != nil {
+		return fmt.Errorf("values are not valid, %w", err)
 	}
 
-	for _, l := range labels {
-		_labels[l.Key] = l.Value
+	valuesYaml, err := EncodeProjectValues(provider, projectName, MasterBranch, values)
+	if err != nil {
+		return fmt.Errorf("unable to encode values yaml, %w", err)
 	}
 
-	mrActionsJsonBts, err := json.Marshal(mrActions)
+	mrExists, err := ProjectHasOpenMR(ctx, projectName, gerritService)
 	if err != nil {
-		return fmt.Errorf("unable to encode mr actions, %w", err)
+		return fmt.Errorf("unable to check project MR exists, %w", err)
+	}
+
+	if mrExists {
+		return MRExists("there is already open merge request(s) for this registry")
 	}
 
-	if err := gerritService.CreateMergeRequestWithContents(ctx, &gerrit.MergeRequest{
+	if err := gerritService.CreateMergeRequestWithContents(userCtx, &gerrit.MergeRequest{
 		ProjectName:   projectName,
-		Name:          fmt.Sprintf("reg-edit-mr-%s-%d", projectName, time.Now().Unix()),
+		Name:          mr.name,
 		AuthorEmail:   ctx.GetString(router.UserEmailSessionKey),
 		AuthorName:    ctx.GetString(router.UserNameSessionKey),
-		CommitMessage: fmt.Sprintf("edit registry"),
-		TargetBranch:  "master",
-		Labels:        _labels,
-		Annotations: map[string]string{
-			MRAnnotationActions: string(mrActionsJsonBts),
-		},
+		CommitMessage: mr.commitMessage,
+		TargetBranch:  MasterBranch,
+		Labels:        mr.labels,
+		Annotations:   mr.annotations,
 	}, map[string]string{
-		ValuesLocation: string(valuesYaml),
+		ValuesLocation: valuesYaml,
 	}); err != nil {
 		return fmt.Errorf("unable to create MR with new values, %w", err)
 	}
@@ -537,18 +602,13 @@ func CreateEditMergeRequest(
 }
 
 func ProjectHasOpenMR(ctx *gin.Context, projectName string, gerritService gerrit.ServiceInterface) (bool, error) {
-	mrs, err := gerritService.GetMergeRequestByProject(ctx, projectName)
+	mrs, err := gerritService.FindMergeRequests(ctx, gerrit.MergeRequestFilter{Project: projectName,
+		Status: gerrit.StatusNew})
 	if err != nil {
 		return false, fmt.Errorf("unable to get MRs, %w", err)
 	}
 
-	for _, mr := range mrs {
-		if mr.Status.Value == gerrit.StatusNew {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return len(mrs) > 0, nil
 }
 
 func validateAdmins(adminsLine string) ([]Admin, error) {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,12 +12,13 @@ import (
 
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"os"
 )
 
 const (
 	ValuesLocation             = "deploy-templates/values.yaml"
-	MRLabelTarget              = "console/target"
+	MRLabelTarget              = gerrit.MRLabelTarget
 	MRLabelSubTarget           = "console/sub-target"
 	MRLabelSourceBranch        = "console/source-branch"
 	MRLabelTargetBranch        = "console/target-branch"
@@ -26,6 +26,7 @@ const (
 	MRLabelActionBranchMerge   = "branch-merge"
 	mrAnnotationRegName        = "ext-reg/name"
 	MRAnnotationActions        = "actions"
+	MRAnnotationMigration      = "values-migration"
 	mrAnnotationRegType        = "ext-reg/type"
 	externalSystemTypeExternal = "external-system"
 	erValuesIndex              = "nontrembita-external-registration"
@@ -92,7 +93,7 @@ func (a *App) addExternalReg(ctx *gin.Context) (router.Response, error) {
 		External: ctx.PostForm("external-system-type") == externalSystemTypeExternal,
 		Enabled:  true,
 	}
-	values, err := a.prepareRegistryValues(userCtx, registryName, &er)
+	values, err := a.prepareRegistryValues(registryName, &er)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to prepare registry values")
 	}
@@ -107,8 +108,8 @@ func (a *App) addExternalReg(ctx *gin.Context) (router.Response, error) {
 		fmt.Sprintf("/admin/registry/view/%s", registryName)), nil
 }
 
-func GetValuesFromGit(projectName, branch string, gerritService gerrit.ServiceInterface) (*Values, error) {
-	content, err := gerritService.GetFileFromBranch(projectName, branch, url.PathEscape(ValuesLocation))
+func GetValuesFromGit(projectName, branch string, provider gitprovider.Provider) (*Values, error) {
+	content, err := provider.GetFile(context.Background(), projectName, branch, ValuesLocation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get the values file from the branch: %w", err)
 	}
@@ -157,20 +158,20 @@ func decodeExternalRegsFromValues(valuesDict map[string]interface{}) ([]External
 	return eRegs, nil
 }
 
-func (a *App) prepareRegistryValues(ctx context.Context, registryName string, er *ExternalRegistration) (string, error) {
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+func (a *App) prepareRegistryValues(registryName string, er *ExternalRegistration) (map[string]any, error) {
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to get values from git")
+		return nil, errors.Wrap(err, "unable to get values from git")
 	}
 
 	eRegs, err := decodeExternalRegsFromValues(values.OriginalYaml)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to decode external regs")
+		return nil, errors.Wrap(err, "unable to decode external regs")
 	}
 
 	for _, _er := range eRegs {
 		if er.Name == _er.Name && _er.External == er.External {
-			return "", errors.New("external reg system already exists")
+			return nil, errors.New("external reg system already exists")
 		}
 	}
 
@@ -181,48 +182,24 @@ func (a *App) prepareRegistryValues(ctx context.Context, registryName string, er
 	})
 	values.OriginalYaml[erValuesIndex] = eRegs
 
-	newValues, err := yaml.Marshal(values.OriginalYaml)
-	if err != nil {
-		return "", errors.Wrap(err, "unable to encode new values yaml")
-	}
-
-	return string(newValues), nil
+	return values.OriginalYaml, nil
 }
 
-func (a *App) createErMergeRequest(userCtx context.Context, ctx *gin.Context, registryName, erName, values, action string) error {
-	mrs, err := a.Services.Gerrit.GetMergeRequestByProject(userCtx, registryName)
-	if err != nil {
-		return errors.Wrap(err, "unable to get MRs")
-	}
-
-	for _, mr := range mrs {
-		if mr.Status.Value == gerrit.StatusNew {
-			return MRExists("there is already open merge request(s) for this registry")
-		}
-	}
-
-	if err := a.Services.Gerrit.CreateMergeRequestWithContents(userCtx, &gerrit.MergeRequest{
-		ProjectName:   registryName,
-		Name:          fmt.Sprintf("ers-mr-%s-%s-%d", registryName, erName, time.Now().Unix()),
-		AuthorEmail:   ctx.GetString(router.UserEmailSessionKey),
-		AuthorName:    ctx.GetString(router.UserNameSessionKey),
-		CommitMessage: fmt.Sprintf("update registry external reg systems"),
-		TargetBranch:  "master",
-		Labels: map[string]string{
-			MRLabelTarget:    mrTargetExternalReg,
-			MRLabelSubTarget: action,
-		},
-		Annotations: map[string]string{
-			mrAnnotationRegName: erName,
-			mrAnnotationRegType: ctx.PostForm("external-system-type"),
-		},
-	}, map[string]string{
-		ValuesLocation: values,
-	}); err != nil {
-		return errors.Wrap(err, "unable to create MR with new values")
-	}
-
-	return nil
+func (a *App) createErMergeRequest(userCtx context.Context, ctx *gin.Context, registryName, erName string,
+	values map[string]any, action string) error {
+	return createEditMergeRequest(ctx, userCtx, registryName, RegistryValuesSchema, values, a.Services.Gerrit,
+		a.GitProvider, &editMergeRequest{
+			name:          fmt.Sprintf("ers-mr-%s-%s-%d", registryName, erName, time.Now().Unix()),
+			commitMessage: "update registry external reg systems",
+			labels: map[string]string{
+				MRLabelTarget:    mrTargetExternalReg,
+				MRLabelSubTarget: action,
+			},
+			annotations: map[string]string{
+				mrAnnotationRegName: erName,
+				mrAnnotationRegType: ctx.PostForm("external-system-type"),
+			},
+		})
 }
 
 func (a *App) disableExternalReg(ctx *gin.Context) (router.Response, error) {
@@ -234,7 +211,7 @@ func (a *App) disableExternalReg(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.New("reg-name is required")
 	}
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values from git")
 	}
@@ -260,12 +237,8 @@ func (a *App) disableExternalReg(ctx *gin.Context) (router.Response, error) {
 	}
 
 	vals.OriginalYaml[erValuesIndex] = eRegs
-	newValues, err := yaml.Marshal(vals.OriginalYaml)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to encode new values yaml")
-	}
 
-	if err := a.createErMergeRequest(userCtx, ctx, registryName, systemName, string(newValues), mrSubTarget); err != nil {
+	if err := a.createErMergeRequest(userCtx, ctx, registryName, systemName, vals.OriginalYaml, mrSubTarget); err != nil {
 		if _, ok := err.(MRExists); !ok {
 			return nil, errors.Wrap(err, "unable to create MR")
 		}
@@ -284,7 +257,7 @@ func (a *App) removeExternalReg(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.New("reg-name is required")
 	}
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values from git")
 	}
@@ -306,12 +279,8 @@ func (a *App) removeExternalReg(ctx *gin.Context) (router.Response, error) {
 	}
 
 	vals.OriginalYaml[erValuesIndex] = eRegs
-	newValues, err := yaml.Marshal(vals.OriginalYaml)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to encode new values yaml")
-	}
 
-	if err := a.createErMergeRequest(userCtx, ctx, registryName, systemName, string(newValues), mrSubTargetDeletion); err != nil {
+	if err := a.createErMergeRequest(userCtx, ctx, registryName, systemName, vals.OriginalYaml, mrSubTargetDeletion); err != nil {
 		if _, ok := err.(MRExists); !ok {
 			return nil, errors.Wrap(err, "unable to create MR")
 		}
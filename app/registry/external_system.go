@@ -73,7 +73,7 @@ func (a *App) getBasicUsername(ctx *gin.Context) (rsp router.Response, retErr er
 		return nil, errors.New("bad request")
 	}
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -115,9 +115,9 @@ func (a *App) deleteExternalSystem(ctx *gin.Context) (rsp router.Response, retEr
 		return nil, errors.Wrap(err, "unable to find registry")
 	}
 
-	exSystemName := ctx.Query("external-system")
+	exSystemName := ctx.PostForm("external-system")
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -135,7 +135,7 @@ func (a *App) deleteExternalSystem(ctx *gin.Context) (rsp router.Response, retEr
 
 	values.OriginalYaml[externalSystemsKey] = values.ExternalSystems
 
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, []string{}); err != nil {
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider, []string{}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
 	}
 
@@ -153,7 +153,7 @@ func (a *App) checkExternalSystemExists(ctx *gin.Context) (rsp router.Response,
 
 	exSystemName := ctx.Query("external-system")
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -179,7 +179,7 @@ func (a *App) createExternalSystemRegistry(ctx *gin.Context) (rsp router.Respons
 		return nil, errors.Wrap(err, "unable to parse form")
 	}
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -207,7 +207,7 @@ func (a *App) createExternalSystemRegistry(ctx *gin.Context) (rsp router.Respons
 		return nil, errors.Wrap(err, "unable to set external system")
 	}
 
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, []string{}); err != nil {
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider, []string{}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
 	}
 
@@ -232,7 +232,7 @@ func (a *App) setExternalSystemRegistryData(ctx *gin.Context) (rsp router.Respon
 
 	var secretPath = externalSystemsSecretPath(a.vaultRegistryPath(registryName), f.RegistryName)
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -260,7 +260,7 @@ func (a *App) setExternalSystemRegistryData(ctx *gin.Context) (rsp router.Respon
 		return nil, errors.Wrap(err, "unable to set external system")
 	}
 
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, []string{}); err != nil {
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider, []string{}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
 	}
 
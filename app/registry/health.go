@@ -0,0 +1,353 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/jenkins"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusGreen  HealthStatus = "green"
+	HealthStatusYellow HealthStatus = "yellow"
+	HealthStatusRed    HealthStatus = "red"
+
+	HealthCheckCodebase      = "codebase"
+	HealthCheckBranches      = "branches"
+	HealthCheckBuildJob      = "build-job"
+	HealthCheckMergeRequests = "merge-requests"
+	HealthCheckWorkloads     = "workloads"
+
+	podReasonCrashLoopBackOff = "CrashLoopBackOff"
+
+	healthCacheTTL     = time.Minute
+	healthBatchWorkers = 4
+)
+
+type HealthCheck struct {
+	Name    string       `json:"name"`
+	Status  HealthStatus `json:"status"`
+	Details []string     `json:"details,omitempty"`
+}
+
+type RegistryHealth struct {
+	Status HealthStatus  `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+func (s HealthStatus) weight() int {
+	switch s {
+	case HealthStatusRed:
+		return 2
+	case HealthStatusYellow:
+		return 1
+	}
+
+	return 0
+}
+
+func worstHealthStatus(statuses ...HealthStatus) HealthStatus {
+	worst := HealthStatusGreen
+	for _, s := range statuses {
+		if s.weight() > worst.weight() {
+			worst = s
+		}
+	}
+
+	return worst
+}
+
+func makeRegistryHealth(checks ...HealthCheck) RegistryHealth {
+	h := RegistryHealth{Status: HealthStatusGreen, Checks: checks}
+	for _, c := range checks {
+		h.Status = worstHealthStatus(h.Status, c.Status)
+	}
+
+	return h
+}
+
+func (a *App) registryHealth(ctx *gin.Context) (router.Response, error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+	cbService, err := a.Services.Codebase.ServiceForContext(userCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	reg, err := cbService.Get(ctx.Param("name"))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry")
+	}
+
+	health, err := a.loadRegistryHealth(userCtx, reg)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load registry health")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, health), nil
+}
+
+// registriesHealth returns health statuses of registries from name query params in one request,
+// statuses are computed by a few workers and cached, so registry list does not fan out per row
+func (a *App) registriesHealth(ctx *gin.Context) (router.Response, error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+	cbService, err := a.Services.Codebase.ServiceForContext(userCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	var (
+		names    = ctx.QueryArray("name")
+		statuses = make(map[string]HealthStatus, len(names))
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		queue    = make(chan string)
+	)
+
+	for i := 0; i < healthBatchWorkers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for name := range queue {
+				status := HealthStatus("unknown")
+
+				if reg, err := cbService.Get(name); err == nil {
+					if health, err := a.cachedRegistryHealth(userCtx, reg); err == nil {
+						status = health.Status
+					}
+				}
+
+				mu.Lock()
+				statuses[name] = status
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range names {
+		queue <- name
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return router.MakeJSONResponse(http.StatusOK, statuses), nil
+}
+
+func (a *App) cachedRegistryHealth(ctx context.Context, reg *codebase.Codebase) (*RegistryHealth, error) {
+	key := "registry-health-" + reg.Name
+	if cached, ok := a.Cache.Get(key); ok {
+		return cached.(*RegistryHealth), nil
+	}
+
+	health, err := a.loadRegistryHealth(ctx, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Cache.Set(key, health, healthCacheTTL)
+
+	return health, nil
+}
+
+func (a *App) loadRegistryHealth(ctx context.Context, reg *codebase.Codebase) (*RegistryHealth, error) {
+	branches, err := a.Services.Codebase.GetBranchesByCodebase(ctx, reg.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry branches")
+	}
+
+	mrs, err := a.Services.Gerrit.GetMergeRequestByProject(ctx, reg.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get merge requests")
+	}
+
+	jobStatus, _, jobErr := a.Services.Jenkins.GetJobStatus(ctx,
+		fmt.Sprintf("%s/view/MASTER/job/MASTER-Build-%s", reg.Name, reg.Name))
+
+	health := makeRegistryHealth(
+		codebaseHealthCheck(reg),
+		branchesHealthCheck(branches),
+		buildJobHealthCheck(jobStatus, jobErr),
+		mergeRequestsHealthCheck(mrs),
+		a.workloadsHealthCheck(ctx, reg.Name),
+	)
+
+	return &health, nil
+}
+
+func codebaseHealthCheck(reg *codebase.Codebase) HealthCheck {
+	check := HealthCheck{Name: HealthCheckCodebase, Status: HealthStatusGreen}
+
+	if reg.StrStatus() == "failed" {
+		check.Status = HealthStatusRed
+		check.Details = append(check.Details, reg.Status.DetailedMessage)
+		return check
+	}
+
+	if reg.ForegroundDeletion() || reg.PendingDeletion() {
+		check.Status = HealthStatusYellow
+		check.Details = append(check.Details, "pending deletion")
+	}
+
+	if !reg.Status.Available {
+		check.Status = HealthStatusYellow
+		check.Details = append(check.Details, fmt.Sprintf("status: %s", reg.Status.Value))
+	}
+
+	if st, ok := reg.Annotations[codebase.StatusAnnotation]; ok && st != "" {
+		check.Status = HealthStatusYellow
+		check.Details = append(check.Details, st)
+	}
+
+	return check
+}
+
+func branchesHealthCheck(branches []codebase.CodebaseBranch) HealthCheck {
+	check := HealthCheck{Name: HealthCheckBranches, Status: HealthStatusGreen}
+
+	for _, b := range branches {
+		switch b.StrStatus() {
+		case codebase.BranchStatusActive:
+			continue
+		case "failed":
+			check.Status = HealthStatusRed
+		default:
+			check.Status = worstHealthStatus(check.Status, HealthStatusYellow)
+		}
+
+		check.Details = append(check.Details, fmt.Sprintf("%s: %s", b.Spec.BranchName, b.StrStatus()))
+	}
+
+	return check
+}
+
+func buildJobHealthCheck(status string, err error) HealthCheck {
+	check := HealthCheck{Name: HealthCheckBuildJob, Status: HealthStatusGreen}
+
+	if err != nil {
+		check.Status = HealthStatusYellow
+		if strings.Contains(err.Error(), "404") {
+			check.Details = append(check.Details, "job not found")
+		} else {
+			check.Details = append(check.Details, err.Error())
+		}
+
+		return check
+	}
+
+	switch status {
+	case jenkins.StatusSuccess:
+	case jenkins.StatusFailure:
+		check.Status = HealthStatusRed
+	case "":
+		check.Status = HealthStatusYellow
+		status = "running"
+	default:
+		check.Status = HealthStatusYellow
+	}
+
+	check.Details = append(check.Details, status)
+
+	return check
+}
+
+func mergeRequestsHealthCheck(mrs []gerrit.GerritMergeRequest) HealthCheck {
+	check := HealthCheck{Name: HealthCheckMergeRequests, Status: HealthStatusGreen}
+
+	for _, mr := range mrs {
+		switch mr.Status.Value {
+		case gerrit.StatusMerged, gerrit.StatusAbandoned:
+			continue
+		case gerrit.StatusNew, "":
+			check.Status = worstHealthStatus(check.Status, HealthStatusYellow)
+			check.Details = append(check.Details, fmt.Sprintf("%s: open", mr.Name))
+		default:
+			check.Status = HealthStatusRed
+			check.Details = append(check.Details, fmt.Sprintf("%s: %s", mr.Name, mr.Status.Value))
+		}
+	}
+
+	return check
+}
+
+func (a *App) workloadsHealthCheck(ctx context.Context, namespace string) HealthCheck {
+	deployments, err := a.Services.K8S.GetDeployments(ctx, namespace)
+	if err != nil {
+		return HealthCheck{Name: HealthCheckWorkloads, Status: HealthStatusYellow, Details: []string{err.Error()}}
+	}
+
+	statefulSets, err := a.Services.K8S.GetStatefulSets(ctx, namespace)
+	if err != nil {
+		return HealthCheck{Name: HealthCheckWorkloads, Status: HealthStatusYellow, Details: []string{err.Error()}}
+	}
+
+	pods, err := a.Services.K8S.GetPods(ctx, namespace)
+	if err != nil {
+		return HealthCheck{Name: HealthCheckWorkloads, Status: HealthStatusYellow, Details: []string{err.Error()}}
+	}
+
+	return workloadsHealthCheck(deployments, statefulSets, pods)
+}
+
+func workloadsHealthCheck(deployments []appsV1.Deployment, statefulSets []appsV1.StatefulSet, pods []v1.Pod) HealthCheck {
+	check := HealthCheck{Name: HealthCheckWorkloads, Status: HealthStatusGreen}
+
+	replicasStatus := func(kind, name string, desired, ready int32) {
+		if ready >= desired {
+			return
+		}
+
+		st := HealthStatusYellow
+		if ready == 0 {
+			st = HealthStatusRed
+		}
+
+		check.Status = worstHealthStatus(check.Status, st)
+		check.Details = append(check.Details, fmt.Sprintf("%s/%s: %d/%d ready", kind, name, ready, desired))
+	}
+
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+
+		replicasStatus("deployment", d.Name, desired, d.Status.ReadyReplicas)
+	}
+
+	for _, s := range statefulSets {
+		desired := int32(1)
+		if s.Spec.Replicas != nil {
+			desired = *s.Spec.Replicas
+		}
+
+		replicasStatus("statefulset", s.Name, desired, s.Status.ReadyReplicas)
+	}
+
+	for _, p := range pods {
+		for _, cs := range p.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == podReasonCrashLoopBackOff {
+				check.Status = HealthStatusRed
+				check.Details = append(check.Details, fmt.Sprintf("pod/%s: %s %s", p.Name, cs.Name,
+					podReasonCrashLoopBackOff))
+			}
+		}
+	}
+
+	return check
+}
@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/gerrit"
+)
+
+func TestWorkloadsHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	replicas := int32(2)
+
+	tests := []struct {
+		name         string
+		deployments  []appsV1.Deployment
+		statefulSets []appsV1.StatefulSet
+		pods         []v1.Pod
+		want         HealthStatus
+	}{
+		{
+			name: "all ready",
+			deployments: []appsV1.Deployment{{
+				ObjectMeta: metav1.ObjectMeta{Name: "api"},
+				Spec:       appsV1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsV1.DeploymentStatus{ReadyReplicas: 2},
+			}},
+			want: HealthStatusGreen,
+		},
+		{
+			name: "partially ready",
+			statefulSets: []appsV1.StatefulSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "db"},
+				Spec:       appsV1.StatefulSetSpec{Replicas: &replicas},
+				Status:     appsV1.StatefulSetStatus{ReadyReplicas: 1},
+			}},
+			want: HealthStatusYellow,
+		},
+		{
+			name: "crash loop",
+			pods: []v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "api-1"},
+				Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "api",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: podReasonCrashLoopBackOff}},
+				}}},
+			}},
+			want: HealthStatusRed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := workloadsHealthCheck(tt.deployments, tt.statefulSets, tt.pods)
+			require.Equal(t, tt.want, got.Status)
+		})
+	}
+}
+
+func TestMakeRegistryHealth(t *testing.T) {
+	t.Parallel()
+
+	h := makeRegistryHealth(
+		buildJobHealthCheck("SUCCESS", nil),
+		mergeRequestsHealthCheck([]gerrit.GerritMergeRequest{{
+			Status: gerrit.GerritMergeRequestStatus{Value: gerrit.StatusNew},
+		}}),
+	)
+	require.Equal(t, HealthStatusYellow, h.Status)
+
+	h = makeRegistryHealth(buildJobHealthCheck("FAILURE", nil), branchesHealthCheck(nil))
+	require.Equal(t, HealthStatusRed, h.Status)
+}
@@ -53,7 +53,7 @@ func (a *App) loadKeycloakHostnames() ([]string, error) {
 }
 
 func (a *App) getClusterValues() (*ClusterValues, error) {
-	data, err := a.Gerrit.GetFileFromBranch(a.ClusterCodebaseName, MasterBranch, url.PathEscape(ValuesLocation))
+	data, err := a.GitProvider.GetFile(context.Background(), a.ClusterCodebaseName, MasterBranch, ValuesLocation)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get cluster values")
 	}
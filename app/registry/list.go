@@ -24,6 +24,7 @@ func (a *App) listRegistry(ctx *gin.Context) (response router.Response, retErr e
 		return nil, errors.Wrap(err, "unable to check codebase creation access")
 	}
 
+	rc := a.Runtime.Load()
 	cbs, err := a.Services.Codebase.GetAllByType(codebase.RegistryCodebaseType)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get codebases")
@@ -33,6 +34,9 @@ func (a *App) listRegistry(ctx *gin.Context) (response router.Response, retErr e
 		return nil, errors.Wrap(err, "unable to load registry versions")
 	}
 
+	// registries of other versions are managed by another console deployment
+	cbs = rc.VersionFilter.FilterCodebases(cbs)
+
 	registries, err := a.Services.Perms.FilterCodebases(ctx, cbs, k8sService)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to check codebase permissions")
@@ -49,10 +53,11 @@ func (a *App) listRegistry(ctx *gin.Context) (response router.Response, retErr e
 		"registries":      registries,
 		"page":            "registry",
 		"allowedToCreate": allowedToCreate,
-		"timezone":        a.Config.Timezone,
+		"timezone":        rc.Timezone,
 		"gerritBranches":  gerritBranches,
-		"platformVersion": a.Config.CurrentVersion,
-		"previousVersion": a.Config.PreviousVersion,
+		"platformVersion": rc.CurrentVersion,
+		"previousVersion": rc.PreviousVersion,
+		"versionFilter":   rc.VersionFilter.String(),
 	}
 
 	templateArgs, templateErr := json.Marshal(responseParams)
@@ -76,6 +81,7 @@ func (a *App) getRegistries(ctx *gin.Context) (rsp router.Response, retErr error
 		return nil, errors.Wrap(err, "unable to check codebase creation access")
 	}
 
+	rc := a.Runtime.Load()
 	cbs, err := a.Services.Codebase.GetAllByType(codebase.RegistryCodebaseType)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get codebases")
@@ -85,6 +91,9 @@ func (a *App) getRegistries(ctx *gin.Context) (rsp router.Response, retErr error
 		return nil, errors.Wrap(err, "unable to load registry versions")
 	}
 
+	// registries of other versions are managed by another console deployment
+	cbs = rc.VersionFilter.FilterCodebases(cbs)
+
 	registries, err := a.Services.Perms.FilterCodebases(ctx, cbs, k8sService)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to check codebase permissions")
@@ -93,37 +102,45 @@ func (a *App) getRegistries(ctx *gin.Context) (rsp router.Response, retErr error
 	return router.MakeJSONResponse(http.StatusOK, registries), nil
 }
 
+// LoadRegistryVersions sets effective versions of registries, versions precomputed by codebase controller
+// are used and only registries which were not reconciled yet are calculated in place
 func LoadRegistryVersions(ctx context.Context, gerritService gerrit.ServiceInterface, cbs []codebase.Codebase) error {
-	mrs, err := gerritService.GetMergeRequests(ctx)
-	if err != nil {
-		return errors.Wrap(err, "unable to get merge requests")
-	}
+	var pending []int
+	for i := range cbs {
+		if v, ok := cbs[i].StoredVersion(); ok {
+			cbs[i].Version = v
+			cbs[i].Spec.DefaultBranch = v.String()
+			continue
+		}
 
-	registryMrs := make(map[string][]gerrit.GerritMergeRequest)
+		pending = append(pending, i)
+	}
 
-	for _, v := range mrs {
-		registryMrs[v.Spec.ProjectName] = append(registryMrs[v.Spec.ProjectName], v)
+	if len(pending) == 0 {
+		return nil
 	}
 
-	for i, cb := range cbs {
-		registryVersion := BranchVersion(cb.Spec.DefaultBranch)
-		if cb.Spec.BranchToCopyInDefaultBranch != "" {
-			registryVersion = BranchVersion(cb.Spec.BranchToCopyInDefaultBranch)
+	// only merged version updates affect registry version, so other merge requests are not loaded
+	var mrs []gerrit.GerritMergeRequest
+	for _, target := range []string{MRTargetRegistryVersionUpdate, MRTargetClusterUpdate} {
+		targetMrs, err := gerritService.FindMergeRequests(ctx, gerrit.MergeRequestFilter{Target: target,
+			Status: gerrit.StatusMerged})
+		if err != nil {
+			return errors.Wrap(err, "unable to get merge requests")
 		}
 
-		currentRegistryMrs := registryMrs[cb.Name]
-		for _, mr := range currentRegistryMrs {
-			if (mr.Labels[MRLabelTarget] == MRTargetRegistryVersionUpdate ||
-				mr.Labels[MRLabelTarget] == MRTargetClusterUpdate) && mr.Status.Value == gerrit.StatusMerged {
-				mergedBranchVersion := BranchVersion(mr.Spec.SourceBranch)
-				if registryVersion.LessThan(mergedBranchVersion) {
-					registryVersion = mergedBranchVersion
-				}
-			}
-		}
+		mrs = append(mrs, targetMrs...)
+	}
+
+	registryMrs := make(map[string][]gerrit.GerritMergeRequest)
+	for _, v := range mrs {
+		registryMrs[v.Spec.ProjectName] = append(registryMrs[v.Spec.ProjectName], v)
+	}
 
-		cbs[i].Version = registryVersion
-		cbs[i].Spec.DefaultBranch = registryVersion.String()
+	for _, i := range pending {
+		rv := EffectiveRegistryVersion(&cbs[i], registryMrs[cbs[i].Name])
+		cbs[i].Version = rv.Version
+		cbs[i].Spec.DefaultBranch = rv.Version.String()
 	}
 
 	return nil
@@ -0,0 +1,312 @@
+package registry
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gosimple/slug"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/keycloak"
+)
+
+const (
+	officersImportFileField = "officers-file"
+	officersListSeparator   = ";"
+)
+
+type Officer struct {
+	Username  string   `json:"username" form:"username" binding:"required"`
+	Email     string   `json:"email" form:"email" binding:"required,email"`
+	FirstName string   `json:"firstName" form:"firstName" binding:"required"`
+	LastName  string   `json:"lastName" form:"lastName" binding:"required"`
+	Enabled   bool     `json:"enabled" form:"-"`
+	Roles     []string `json:"roles" form:"-"`
+	Groups    []string `json:"groups" form:"-"`
+	Status    string   `json:"status,omitempty" form:"-"`
+}
+
+type officerAccess struct {
+	Username string `form:"username" binding:"required"`
+	Roles    string `form:"roles"`
+	Groups   string `form:"groups"`
+}
+
+type OfficersImportResult struct {
+	Created []string `json:"created"`
+	Skipped []string `json:"skipped"`
+	Errors  []string `json:"errors"`
+}
+
+func (a *App) listOfficers(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if err := a.checkOfficersAccess(ctx, registryName, false); err != nil {
+		return nil, err
+	}
+
+	usrs, err := a.Services.Keycloak.GetUsersByNamespace(ctx, registryName, a.Config.OfficersRealm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get officers")
+	}
+
+	officers := make([]Officer, 0, len(usrs))
+	for _, u := range usrs {
+		officers = append(officers, Officer{
+			Username:  u.Spec.Username,
+			Email:     u.Spec.Email,
+			FirstName: u.Spec.FirstName,
+			LastName:  u.Spec.LastName,
+			Enabled:   u.Spec.Enabled,
+			Roles:     u.Spec.Roles,
+			Groups:    u.Spec.Groups,
+			Status:    u.Status.Value,
+		})
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, officers), nil
+}
+
+func (a *App) createOfficer(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if err := a.checkOfficersAccess(ctx, registryName, true); err != nil {
+		return nil, err
+	}
+
+	var o Officer
+	if err := ctx.ShouldBind(&o); err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	o.Roles = splitOfficersList(ctx.PostForm("roles"))
+	o.Groups = splitOfficersList(ctx.PostForm("groups"))
+
+	if err := a.Services.Keycloak.CreateUser(ctx, a.officerRealmUser(registryName, &o)); err != nil {
+		if k8sErrors.IsAlreadyExists(errors.Cause(err)) {
+			return router.MakeJSONResponse(http.StatusConflict, gin.H{"error": "officer already exists"}), nil
+		}
+
+		return nil, errors.Wrap(err, "unable to create officer")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, o), nil
+}
+
+func (a *App) setOfficerEnabled(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if err := a.checkOfficersAccess(ctx, registryName, true); err != nil {
+		return nil, err
+	}
+
+	u, err := a.getOfficer(ctx, registryName, ctx.PostForm("username"))
+	if err != nil {
+		return nil, err
+	}
+
+	u.Spec.Enabled = ctx.PostForm("enabled") == "true"
+	if err := a.Services.Keycloak.UpdateUser(ctx, u); err != nil {
+		return nil, errors.Wrap(err, "unable to update officer")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+func (a *App) setOfficerAccess(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if err := a.checkOfficersAccess(ctx, registryName, true); err != nil {
+		return nil, err
+	}
+
+	var access officerAccess
+	if err := ctx.ShouldBind(&access); err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	u, err := a.getOfficer(ctx, registryName, access.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Spec.Roles = splitOfficersList(access.Roles)
+	u.Spec.Groups = splitOfficersList(access.Groups)
+
+	if err := a.Services.Keycloak.UpdateUser(ctx, u); err != nil {
+		return nil, errors.Wrap(err, "unable to update officer")
+	}
+
+	return router.MakeStatusResponse(http.StatusOK), nil
+}
+
+func (a *App) importOfficers(ctx *gin.Context) (router.Response, error) {
+	registryName := ctx.Param("name")
+	if err := a.checkOfficersAccess(ctx, registryName, true); err != nil {
+		return nil, err
+	}
+
+	fh, err := ctx.FormFile(officersImportFileField)
+	if err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": "officers file is required"}), nil
+	}
+
+	fp, err := fh.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open officers file")
+	}
+	defer fp.Close()
+
+	officers, err := parseOfficersCSV(fp)
+	if err != nil {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": err.Error()}), nil
+	}
+
+	var result OfficersImportResult
+	for i := range officers {
+		if err := a.Services.Keycloak.CreateUser(ctx, a.officerRealmUser(registryName, &officers[i])); err != nil {
+			if k8sErrors.IsAlreadyExists(errors.Cause(err)) {
+				result.Skipped = append(result.Skipped, officers[i].Username)
+				continue
+			}
+
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", officers[i].Username, err.Error()))
+			continue
+		}
+
+		result.Created = append(result.Created, officers[i].Username)
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, result), nil
+}
+
+func (a *App) checkOfficersAccess(ctx *gin.Context, registryName string, modify bool) error {
+	k8sService, err := a.Services.K8S.ServiceForContext(router.ContextWithUserAccessToken(ctx))
+	if err != nil {
+		return errors.Wrap(err, "unable to init service for user context")
+	}
+
+	canGet, canUpdate, _, err := codebase.CheckCodebasePermission(registryName, k8sService)
+	if err != nil {
+		return errors.Wrap(err, "unable to check registry permissions")
+	}
+
+	if !canGet || (modify && !canUpdate) {
+		return errors.New("access denied")
+	}
+
+	return nil
+}
+
+func (a *App) getOfficer(ctx context.Context, registryName, username string) (*keycloak.KeycloakRealmUser, error) {
+	usrs, err := a.Services.Keycloak.GetUsersByNamespace(ctx, registryName, a.Config.OfficersRealm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get officers")
+	}
+
+	for i := range usrs {
+		if usrs[i].Spec.Username == username {
+			return &usrs[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("officer %s not found", username)
+}
+
+func (a *App) officerRealmUser(registryName string, o *Officer) *keycloak.KeycloakRealmUser {
+	return &keycloak.KeycloakRealmUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      officerK8SNameFromUsername(o.Username),
+			Namespace: registryName,
+		},
+		Spec: keycloak.KeycloakRealmUserSpec{
+			Realm:         a.Config.OfficersRealm,
+			Username:      o.Username,
+			Email:         o.Email,
+			FirstName:     o.FirstName,
+			LastName:      o.LastName,
+			Roles:         o.Roles,
+			Groups:        o.Groups,
+			Enabled:       true,
+			EmailVerified: true,
+			KeepResource:  true,
+		},
+	}
+}
+
+func officerK8SNameFromUsername(username string) string {
+	return fmt.Sprintf("officer-%s",
+		strings.Replace(slug.Make(username), "_", "-", -1))
+}
+
+func splitOfficersList(in string) []string {
+	var res []string
+	for _, s := range strings.Split(in, officersListSeparator) {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+// parseOfficersCSV reads officers from csv with header: username,email,firstName,lastName,roles,groups
+// where roles and groups are separated by semicolon
+func parseOfficersCSV(r io.Reader) ([]Officer, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read csv")
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("csv file has no officers")
+	}
+
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		columns[strings.TrimSpace(h)] = i
+	}
+
+	for _, required := range []string{"username", "email", "firstName", "lastName"} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.Errorf("csv column %s is missing", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	officers := make([]Officer, 0, len(records)-1)
+	for n, record := range records[1:] {
+		o := Officer{
+			Username:  field(record, "username"),
+			Email:     field(record, "email"),
+			FirstName: field(record, "firstName"),
+			LastName:  field(record, "lastName"),
+			Roles:     splitOfficersList(field(record, "roles")),
+			Groups:    splitOfficersList(field(record, "groups")),
+		}
+
+		if o.Username == "" || o.Email == "" {
+			return nil, errors.Errorf("line %d: username and email are required", n+2)
+		}
+
+		officers = append(officers, o)
+	}
+
+	return officers, nil
+}
@@ -0,0 +1,29 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOfficersCSV(t *testing.T) {
+	t.Parallel()
+
+	officers, err := parseOfficersCSV(strings.NewReader(
+		"username,email,firstName,lastName,roles,groups\n" +
+			"jdoe,jdoe@example.com,John,Doe,officer;head-officer,\n" +
+			"asmith, asmith@example.com,Anna,Smith,,inspectors\n"))
+	require.NoError(t, err)
+	require.Len(t, officers, 2)
+	require.Equal(t, []string{"officer", "head-officer"}, officers[0].Roles)
+	require.Nil(t, officers[0].Groups)
+	require.Equal(t, "asmith@example.com", officers[1].Email)
+	require.Equal(t, []string{"inspectors"}, officers[1].Groups)
+
+	_, err = parseOfficersCSV(strings.NewReader("username,email\njdoe,jdoe@example.com\n"))
+	require.Error(t, err)
+
+	_, err = parseOfficersCSV(strings.NewReader("username,email,firstName,lastName\n,jdoe@example.com,John,Doe\n"))
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"context"
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/gerrit"
 	"ddm-admin-console/locale"
@@ -8,7 +9,6 @@ import (
 	"fmt"
 	"net/http"
 
-	goGerrit "github.com/andygrunwald/go-gerrit"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -41,7 +41,7 @@ func (a *App) editPublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.Wrap(err, locale.Localize("registry.errors.regLimitsDecode"))
 	}
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values from git, %w", err)
 	}
@@ -65,7 +65,7 @@ func (a *App) editPublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		ctx,
 		registryName,
 		vals.OriginalYaml,
-		a.Gerrit, []string{},
+		a.Gerrit, a.GitProvider, []string{},
 		MRLabel{Key: MRLabelPublicApiTarget, Value: mrTargetPublicAPIReg},
 		MRLabel{Key: MRLabelPublicApiName, Value: ctx.PostForm("reg-name")},
 		MRLabel{Key: MRLabelPublicApiSubTarget, Value: "edition"},
@@ -93,7 +93,7 @@ func (a *App) addPublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		Enabled: true,
 	}
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values from git, %w", err)
 	}
@@ -119,7 +119,7 @@ func (a *App) addPublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		ctx,
 		registryName,
 		values.OriginalYaml,
-		a.Gerrit, []string{},
+		a.Gerrit, a.GitProvider, []string{},
 		MRLabel{Key: MRLabelPublicApiTarget, Value: mrTargetPublicAPIReg},
 		MRLabel{Key: MRLabelPublicApiName, Value: ctx.PostForm("reg-name")},
 		MRLabel{Key: MRLabelPublicApiSubTarget, Value: "creation"},
@@ -138,7 +138,7 @@ func (a *App) disablePublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.New("reg-name is required")
 	}
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values from git, %w", err)
 	}
@@ -169,7 +169,7 @@ func (a *App) disablePublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		ctx,
 		registryName,
 		vals.OriginalYaml,
-		a.Gerrit, []string{},
+		a.Gerrit, a.GitProvider, []string{},
 		MRLabel{Key: MRLabelPublicApiTarget, Value: mrTargetPublicAPIReg},
 		MRLabel{Key: MRLabelPublicApiName, Value: ctx.PostForm("reg-name")},
 		MRLabel{Key: MRLabelPublicApiSubTarget, Value: action},
@@ -188,7 +188,7 @@ func (a *App) removePublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		return nil, errors.New("reg-name is required")
 	}
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get values from git, %w", err)
 	}
@@ -218,7 +218,7 @@ func (a *App) removePublicAPIReg(ctx *gin.Context) (router.Response, error) {
 		ctx,
 		registryName,
 		vals.OriginalYaml,
-		a.Gerrit, []string{},
+		a.Gerrit, a.GitProvider, []string{},
 		MRLabel{Key: MRLabelPublicApiTarget, Value: mrTargetPublicAPIReg},
 		MRLabel{Key: MRLabelPublicApiName, Value: ctx.PostForm("reg-name")},
 		MRLabel{Key: MRLabelPublicApiSubTarget, Value: "deletion"},
@@ -237,15 +237,9 @@ func (a *App) makeViewPublicAPIMR(mr gerrit.GerritMergeRequest, registryName str
 		return publicAPI, nil
 	}
 
-	changeInfo, _, err := a.Gerrit.GoGerritClient().Changes.GetChangeDetail(mr.Status.ChangeID, &goGerrit.ChangeOptions{})
-
-	if err != nil {
-		return publicAPI, fmt.Errorf("unable to get gerrit change details, %w", err)
-	}
-	changesContent, err := a.getChangeContentData(changeInfo.ID, ValuesLocation, changeInfo.Project)
-
+	changesContent, err := a.GitProvider.GetChangeFile(context.Background(), mr.Status.ChangeID, ValuesLocation)
 	if err != nil {
-		return publicAPI, fmt.Errorf("unable to get gerrit change, %w", err)
+		return publicAPI, fmt.Errorf("unable to get change values, %w", err)
 	}
 
 	var (
@@ -259,7 +253,7 @@ func (a *App) makeViewPublicAPIMR(mr gerrit.GerritMergeRequest, registryName str
 
 	name := mr.Labels[MRLabelPublicApiName]
 
-	vals, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	vals, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return publicAPI, fmt.Errorf("unable to get values from git, %w", err)
 	}
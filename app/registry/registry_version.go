@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/gerrit"
+)
+
+const (
+	VersionSourceDefaultBranch   = "default-branch"
+	VersionSourceProjectBranches = "project-branches"
+)
+
+// RegistryVersion is an effective registry version, source is a target of merge request which upgraded
+// registry or the way version was detected when registry was not upgraded yet
+type RegistryVersion struct {
+	Version    *version.Version
+	UpgradedAt *time.Time
+	Source     string
+}
+
+// EffectiveRegistryVersion calculates registry version from its default branch and merged update merge requests
+func EffectiveRegistryVersion(cb *codebase.Codebase, mrs []gerrit.GerritMergeRequest) RegistryVersion {
+	rv := RegistryVersion{
+		Version: BranchVersion(cb.Spec.DefaultBranch),
+		Source:  VersionSourceDefaultBranch,
+	}
+
+	if cb.Spec.BranchToCopyInDefaultBranch != "" {
+		rv.Version = BranchVersion(cb.Spec.BranchToCopyInDefaultBranch)
+	}
+
+	for _, mr := range mrs {
+		target := mr.Labels[MRLabelTarget]
+		if mr.Spec.ProjectName != cb.Name || mr.Status.Value != gerrit.StatusMerged ||
+			(target != MRTargetRegistryVersionUpdate && target != MRTargetClusterUpdate) {
+			continue
+		}
+
+		mergedBranchVersion := BranchVersion(mr.Spec.SourceBranch)
+		if rv.Version.LessThan(mergedBranchVersion) {
+			// merge time is not tracked by merge request, its creation is the closest known time
+			upgradedAt := mr.CreationTimestamp.UTC()
+			rv.Version = mergedBranchVersion
+			rv.UpgradedAt = &upgradedAt
+			rv.Source = target
+		}
+	}
+
+	return rv
+}
+
+// ComputeRegistryVersion calculates registry version, lowest update branch of registry project is used
+// when version can not be detected otherwise
+func ComputeRegistryVersion(ctx context.Context, gerritService gerrit.ServiceInterface,
+	cb *codebase.Codebase) (*RegistryVersion, error) {
+	mrs, err := gerritService.FindMergeRequests(ctx, gerrit.MergeRequestFilter{Project: cb.Name,
+		Status: gerrit.StatusMerged})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get merge requests")
+	}
+
+	rv := EffectiveRegistryVersion(cb, mrs)
+	if rv.Version.Original() != "0" {
+		return &rv, nil
+	}
+
+	prj, err := gerritService.GetProject(ctx, cb.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry gerrit project")
+	}
+
+	rv.Version = LowestVersion(UpdateBranches(prj.Status.Branches))
+	rv.Source = VersionSourceProjectBranches
+
+	return &rv, nil
+}
+
+// SetRegistryVersionAnnotations stores registry version on codebase, returns false if nothing changed
+func SetRegistryVersionAnnotations(cb *codebase.Codebase, rv *RegistryVersion) bool {
+	annotations := map[string]string{
+		codebase.VersionAnnotation:       rv.Version.Original(),
+		codebase.VersionSourceAnnotation: rv.Source,
+	}
+
+	if rv.UpgradedAt != nil {
+		annotations[codebase.VersionUpgradedAtAnnotation] = rv.UpgradedAt.Format(time.RFC3339)
+	}
+
+	changed := false
+	if _, ok := cb.Annotations[codebase.VersionUpgradedAtAnnotation]; ok && rv.UpgradedAt == nil {
+		delete(cb.Annotations, codebase.VersionUpgradedAtAnnotation)
+		changed = true
+	}
+
+	if cb.Annotations == nil {
+		cb.Annotations = make(map[string]string)
+	}
+
+	for k, v := range annotations {
+		if cb.Annotations[k] != v {
+			cb.Annotations[k] = v
+			changed = true
+		}
+	}
+
+	return changed
+}
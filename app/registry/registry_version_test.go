@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mockGerrit "ddm-admin-console/mocks/gerrit"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/gerrit"
+)
+
+func TestEffectiveRegistryVersion(t *testing.T) {
+	t.Parallel()
+
+	upgradedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	cb := codebase.Codebase{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry"},
+		Spec:       codebase.CodebaseSpec{DefaultBranch: "master", BranchToCopyInDefaultBranch: "1.9.5"},
+	}
+
+	mergedMR := func(target, branch string, createdAt time.Time) gerrit.GerritMergeRequest {
+		return gerrit.GerritMergeRequest{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{MRLabelTarget: target},
+				CreationTimestamp: metav1.NewTime(createdAt)},
+			Spec:   gerrit.GerritMergeRequestSpec{ProjectName: "registry", SourceBranch: branch},
+			Status: gerrit.GerritMergeRequestStatus{Value: gerrit.StatusMerged},
+		}
+	}
+
+	rv := EffectiveRegistryVersion(&cb, nil)
+	require.Equal(t, "1.9.5", rv.Version.Original())
+	require.Equal(t, VersionSourceDefaultBranch, rv.Source)
+	require.Nil(t, rv.UpgradedAt)
+
+	rv = EffectiveRegistryVersion(&cb, []gerrit.GerritMergeRequest{
+		mergedMR(MRTargetRegistryVersionUpdate, "1.9.6", upgradedAt.Add(-time.Hour)),
+		mergedMR(MRTargetClusterUpdate, "1.9.7", upgradedAt),
+		mergedMR("registry-edit", "1.9.8", upgradedAt),
+	})
+	require.Equal(t, "1.9.7", rv.Version.Original())
+	require.Equal(t, MRTargetClusterUpdate, rv.Source)
+	require.Equal(t, upgradedAt, *rv.UpgradedAt)
+
+	require.True(t, SetRegistryVersionAnnotations(&cb, &rv))
+	require.False(t, SetRegistryVersionAnnotations(&cb, &rv))
+	require.Equal(t, "2023-03-01T10:00:00Z", cb.Annotations[codebase.VersionUpgradedAtAnnotation])
+
+	stored, ok := cb.StoredVersion()
+	require.True(t, ok)
+	require.Equal(t, "1.9.7", stored.Original())
+}
+
+func TestLoadRegistryVersions(t *testing.T) {
+	t.Parallel()
+
+	gerritService := mockGerrit.ServiceInterface{}
+	gerritService.On("FindMergeRequests", mock.Anything, gerrit.MergeRequestFilter{
+		Target: MRTargetRegistryVersionUpdate, Status: gerrit.StatusMerged}).Return([]gerrit.GerritMergeRequest{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{MRLabelTarget: MRTargetRegistryVersionUpdate}},
+			Spec:       gerrit.GerritMergeRequestSpec{ProjectName: "pending", SourceBranch: "1.9.6"},
+			Status:     gerrit.GerritMergeRequestStatus{Value: gerrit.StatusMerged},
+		},
+	}, nil)
+	gerritService.On("FindMergeRequests", mock.Anything, gerrit.MergeRequestFilter{
+		Target: MRTargetClusterUpdate, Status: gerrit.StatusMerged}).Return([]gerrit.GerritMergeRequest{}, nil)
+
+	cbs := []codebase.Codebase{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stored",
+				Annotations: map[string]string{codebase.VersionAnnotation: "1.9.7"}},
+			Spec: codebase.CodebaseSpec{DefaultBranch: "master"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending"},
+			Spec:       codebase.CodebaseSpec{DefaultBranch: "1.9.5"},
+		},
+	}
+
+	require.NoError(t, LoadRegistryVersions(context.Background(), &gerritService, cbs))
+	require.Equal(t, "1.9.7", cbs[0].Version.Original())
+	require.Equal(t, "1.9.6", cbs[1].Version.Original())
+
+	require.NoError(t, LoadRegistryVersions(context.Background(), &gerritService, cbs[:1]))
+	gerritService.AssertNumberOfCalls(t, "FindMergeRequests", 2)
+}
@@ -0,0 +1,333 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/k8s"
+)
+
+const (
+	ResourceFlagOverProvisioned  = "over-provisioned"
+	ResourceFlagUnderProvisioned = "under-provisioned"
+	ResourceFlagConfigDrift      = "config-drift"
+	ResourceFlagNotRunning       = "not-running"
+
+	istioSidecarContainerName = "istio-proxy"
+	// usage below this share of requests is treated as over-provisioning
+	overProvisionedRatio = 0.2
+	// usage above this share of limits is treated as under-provisioning
+	underProvisionedRatio = 0.9
+)
+
+type ResourceValues struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+type ResourceSpec struct {
+	Requests ResourceValues `json:"requests"`
+	Limits   ResourceValues `json:"limits"`
+}
+
+type ServiceResources struct {
+	Name       string          `json:"name"`
+	Replicas   int             `json:"replicas"`
+	Pods       int             `json:"pods"`
+	Configured ResourceSpec    `json:"configured"`
+	Actual     ResourceSpec    `json:"actual"`
+	Usage      *ResourceValues `json:"usage,omitempty"`
+	Flags      []string        `json:"flags,omitempty"`
+}
+
+type QuotaResource struct {
+	Name string `json:"name"`
+	Hard string `json:"hard"`
+	Used string `json:"used"`
+}
+
+type QuotaUsage struct {
+	Name      string          `json:"name"`
+	Resources []QuotaResource `json:"resources"`
+}
+
+type RegistryResourceUsage struct {
+	Quotas           []QuotaUsage       `json:"quotas"`
+	Services         []ServiceResources `json:"services"`
+	MetricsAvailable bool               `json:"metricsAvailable"`
+}
+
+func (a *App) registryResourceUsage(ctx *gin.Context) (router.Response, error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+	cbService, err := a.Services.Codebase.ServiceForContext(userCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	reg, err := cbService.Get(ctx.Param("name"))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry")
+	}
+
+	values, err := GetValuesFromGit(reg.Name, MasterBranch, a.Services.GitProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get values from git")
+	}
+
+	quotas, err := a.Services.K8S.GetResourceQuotas(userCtx, reg.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get resource quotas")
+	}
+
+	pods, err := a.Services.K8S.GetPods(userCtx, reg.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get pods")
+	}
+
+	usage := RegistryResourceUsage{Quotas: convertQuotas(quotas)}
+
+	podMetrics := make(map[string]k8s.PodMetrics)
+	// metrics-server is optional, usage is just not shown without it
+	if metrics, err := a.Services.K8S.GetPodMetrics(userCtx, reg.Name); err == nil {
+		usage.MetricsAvailable = true
+		for _, m := range metrics {
+			podMetrics[m.Name] = m
+		}
+	}
+
+	serviceNames := make([]string, 0, len(values.Global.Registry))
+	for name := range values.Global.Registry {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	for _, name := range serviceNames {
+		cnf, _ := values.Global.Registry[name].(map[string]any)
+		usage.Services = append(usage.Services, makeServiceResources(name, cnf, servicePods(name, pods), podMetrics))
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, usage), nil
+}
+
+func convertQuotas(quotas []v1.ResourceQuota) []QuotaUsage {
+	res := make([]QuotaUsage, 0, len(quotas))
+	for _, q := range quotas {
+		qu := QuotaUsage{Name: q.Name}
+		for name, hard := range q.Status.Hard {
+			used := q.Status.Used[name]
+			qu.Resources = append(qu.Resources, QuotaResource{
+				Name: string(name),
+				Hard: hard.String(),
+				Used: used.String(),
+			})
+		}
+
+		sort.Slice(qu.Resources, func(i, j int) bool {
+			return qu.Resources[i].Name < qu.Resources[j].Name
+		})
+		res = append(res, qu)
+	}
+
+	return res
+}
+
+func servicePods(serviceName string, pods []v1.Pod) []v1.Pod {
+	var res []v1.Pod
+	for _, p := range pods {
+		if p.Status.Phase != v1.PodRunning {
+			continue
+		}
+
+		if p.Labels["app"] == serviceName || p.Labels["app.kubernetes.io/name"] == serviceName {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+func makeServiceResources(name string, cnf map[string]any, pods []v1.Pod, podMetrics map[string]k8s.PodMetrics) ServiceResources {
+	sr := ServiceResources{
+		Name:       name,
+		Replicas:   configuredReplicas(cnf),
+		Pods:       len(pods),
+		Configured: configuredResources(cnf),
+	}
+
+	if len(pods) == 0 {
+		sr.Flags = append(sr.Flags, ResourceFlagNotRunning)
+		return sr
+	}
+
+	requests, limits := v1.ResourceList{}, v1.ResourceList{}
+	for _, c := range pods[0].Spec.Containers {
+		if c.Name == istioSidecarContainerName {
+			continue
+		}
+
+		addResources(requests, c.Resources.Requests)
+		addResources(limits, c.Resources.Limits)
+	}
+
+	sr.Actual = ResourceSpec{Requests: resourceValues(requests), Limits: resourceValues(limits)}
+
+	if quantityDiffers(sr.Configured.Requests.CPU, requests, v1.ResourceCPU) ||
+		quantityDiffers(sr.Configured.Requests.Memory, requests, v1.ResourceMemory) ||
+		quantityDiffers(sr.Configured.Limits.CPU, limits, v1.ResourceCPU) ||
+		quantityDiffers(sr.Configured.Limits.Memory, limits, v1.ResourceMemory) {
+		sr.Flags = append(sr.Flags, ResourceFlagConfigDrift)
+	}
+
+	usage, measured := averageUsage(pods, podMetrics)
+	if measured == 0 {
+		return sr
+	}
+
+	usageValues := resourceValues(usage)
+	sr.Usage = &usageValues
+
+	for _, rName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		used := usage[rName]
+
+		if req, ok := requests[rName]; ok && !req.IsZero() &&
+			used.AsApproximateFloat64() < req.AsApproximateFloat64()*overProvisionedRatio {
+			sr.Flags = appendFlag(sr.Flags, ResourceFlagOverProvisioned)
+		}
+
+		if lim, ok := limits[rName]; ok && !lim.IsZero() &&
+			used.AsApproximateFloat64() > lim.AsApproximateFloat64()*underProvisionedRatio {
+			sr.Flags = appendFlag(sr.Flags, ResourceFlagUnderProvisioned)
+		}
+	}
+
+	return sr
+}
+
+func averageUsage(pods []v1.Pod, podMetrics map[string]k8s.PodMetrics) (v1.ResourceList, int) {
+	total := v1.ResourceList{}
+	measured := 0
+
+	for _, p := range pods {
+		m, ok := podMetrics[p.Name]
+		if !ok {
+			continue
+		}
+
+		for _, c := range m.Containers {
+			if c.Name == istioSidecarContainerName {
+				continue
+			}
+
+			addResources(total, c.Usage)
+		}
+		measured++
+	}
+
+	if measured == 0 {
+		return total, 0
+	}
+
+	avg := v1.ResourceList{}
+	for name, q := range total {
+		if name == v1.ResourceCPU {
+			avg[name] = *resource.NewMilliQuantity(q.MilliValue()/int64(measured), resource.DecimalSI)
+			continue
+		}
+
+		avg[name] = *resource.NewQuantity(q.Value()/int64(measured), resource.BinarySI)
+	}
+
+	return avg, measured
+}
+
+func addResources(dst, src v1.ResourceList) {
+	for name, q := range src {
+		cur := dst[name]
+		cur.Add(q)
+		dst[name] = cur
+	}
+}
+
+func resourceValues(lst v1.ResourceList) ResourceValues {
+	var rv ResourceValues
+	if q, ok := lst[v1.ResourceCPU]; ok {
+		rv.CPU = q.String()
+	}
+
+	if q, ok := lst[v1.ResourceMemory]; ok {
+		rv.Memory = q.String()
+	}
+
+	return rv
+}
+
+func quantityDiffers(configured string, actual v1.ResourceList, name v1.ResourceName) bool {
+	if configured == "" {
+		return false
+	}
+
+	cq, err := resource.ParseQuantity(configured)
+	if err != nil {
+		return true
+	}
+
+	aq, ok := actual[name]
+	if !ok {
+		return true
+	}
+
+	return cq.Cmp(aq) != 0
+}
+
+func appendFlag(flags []string, flag string) []string {
+	for _, f := range flags {
+		if f == flag {
+			return flags
+		}
+	}
+
+	return append(flags, flag)
+}
+
+func configuredReplicas(cnf map[string]any) int {
+	switch r := cnf["replicas"].(type) {
+	case int:
+		return r
+	case float64:
+		return int(r)
+	}
+
+	return 0
+}
+
+func configuredResources(cnf map[string]any) ResourceSpec {
+	container, _ := cnf["container"].(map[string]any)
+	resources, _ := container["resources"].(map[string]any)
+
+	return ResourceSpec{
+		Requests: mapResourceValues(resources["requests"]),
+		Limits:   mapResourceValues(resources["limits"]),
+	}
+}
+
+func mapResourceValues(in any) ResourceValues {
+	m, _ := in.(map[string]any)
+
+	var rv ResourceValues
+	if cpu, ok := m["cpu"]; ok {
+		rv.CPU = fmt.Sprint(cpu)
+	}
+
+	if memory, ok := m["memory"]; ok {
+		rv.Memory = fmt.Sprint(memory)
+	}
+
+	return rv
+}
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/k8s"
+)
+
+func TestMakeServiceResources(t *testing.T) {
+	t.Parallel()
+
+	cnf := map[string]any{
+		"replicas": 1,
+		"container": map[string]any{
+			"resources": map[string]any{
+				"requests": map[string]any{"cpu": "500m", "memory": "512Mi"},
+				"limits":   map[string]any{"cpu": "1", "memory": "1Gi"},
+			},
+		},
+	}
+
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bpms-1", Labels: map[string]string{"app": "bpms"}},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{
+				Name: "bpms",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("500m"),
+						v1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			{
+				Name: istioSidecarContainerName,
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU: resource.MustParse("100m"),
+				}},
+			},
+		}},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+
+	tests := []struct {
+		name    string
+		cnf     map[string]any
+		pods    []v1.Pod
+		metrics map[string]k8s.PodMetrics
+		want    []string
+	}{
+		{
+			name: "not running",
+			cnf:  cnf,
+			want: []string{ResourceFlagNotRunning},
+		},
+		{
+			name: "no metrics",
+			cnf:  cnf,
+			pods: []v1.Pod{pod},
+		},
+		{
+			name: "over provisioned",
+			cnf:  cnf,
+			pods: []v1.Pod{pod},
+			metrics: map[string]k8s.PodMetrics{"bpms-1": {Containers: []k8s.ContainerMetrics{{
+				Name: "bpms",
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("10m"),
+					v1.ResourceMemory: resource.MustParse("300Mi"),
+				},
+			}}}},
+			want: []string{ResourceFlagOverProvisioned},
+		},
+		{
+			name: "under provisioned with drift",
+			cnf: map[string]any{"container": map[string]any{"resources": map[string]any{
+				"requests": map[string]any{"cpu": "250m"},
+			}}},
+			pods: []v1.Pod{pod},
+			metrics: map[string]k8s.PodMetrics{"bpms-1": {Containers: []k8s.ContainerMetrics{{
+				Name: "bpms",
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("400m"),
+					v1.ResourceMemory: resource.MustParse("1000Mi"),
+				},
+			}}}},
+			want: []string{ResourceFlagConfigDrift, ResourceFlagUnderProvisioned},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sr := makeServiceResources("bpms", tt.cnf, servicePods("bpms", tt.pods), tt.metrics)
+			require.Equal(t, tt.want, sr.Flags)
+		})
+	}
+}
@@ -1,10 +1,10 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"reflect"
 	"strconv"
 
@@ -16,7 +16,7 @@ import (
 )
 
 func (a *App) getValuesFromBranch(project, branch string) (map[string]any, error) {
-	content, err := a.Gerrit.GetFileFromBranch(project, branch, url.PathEscape(ValuesLocation))
+	content, err := a.GitProvider.GetFile(context.Background(), project, branch, ValuesLocation)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get project content")
 	}
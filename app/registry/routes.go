@@ -1,5 +1,7 @@
 package registry
 
+import "ddm-admin-console/router"
+
 func (a *App) createRoutes() {
 	a.router.GET("/admin/registry/create", a.createRegistryGet)
 	a.router.POST("/admin/registry/create", a.createRegistryPost)
@@ -9,33 +11,54 @@ func (a *App) createRoutes() {
 
 	a.router.GET("/admin/registry/overview", a.listRegistry)
 	a.router.POST("/admin/registry/overview", a.deleteRegistry)
-	a.router.GET("/admin/registries", a.getRegistries)
+	a.router.POST("/admin/registry/restore/:name", a.restoreRegistry)
+	a.router.GET("/admin/registries", a.getRegistries, router.SkipCSP)
 
 	a.router.POST("/admin/registry/check-pem", a.validatePEMFile)
 
-	a.router.GET("/admin/registry/check/:name", a.registryNameAvailable)
+	a.router.GET("/admin/registry/check/:name", a.registryNameAvailable, router.SkipCSP)
 	a.router.GET("/admin/registry/view/:name", a.viewRegistry)
+	a.router.GET("/admin/registry/health", a.registriesHealth, router.SkipCSP)
+	a.router.GET("/admin/registry/health/:name", a.registryHealth, router.SkipCSP)
+	a.router.GET("/admin/registry/resource-usage/:name", a.registryResourceUsage, router.SkipCSP)
+
+	a.router.GET("/admin/registry/officers/:name", a.listOfficers, router.SkipCSP)
+	a.router.POST("/admin/registry/officer-create/:name", a.createOfficer)
+	a.router.POST("/admin/registry/officer-enabled/:name", a.setOfficerEnabled)
+	a.router.POST("/admin/registry/officer-access/:name", a.setOfficerAccess)
+	a.router.POST("/admin/registry/officer-import/:name", a.importOfficers)
+
+	a.router.GET("/admin/registry/access/:name", a.registryAccess, router.SkipCSP)
+	a.router.POST("/admin/registry/access-grant/:name", a.grantRegistryAccess)
+	a.router.POST("/admin/registry/access-revoke/:name", a.revokeRegistryAccess)
+
+	a.router.GET("/admin/registry/admin-lifecycle/:name", a.adminLifecycle, router.SkipCSP)
+	a.router.POST("/admin/registry/admin-reset-password/:name", a.adminResetPassword)
+	a.router.POST("/admin/registry/admin-enabled/:name", a.adminSetEnabled)
+	a.router.POST("/admin/registry/admin-expiry/:name", a.adminSetExpiry)
+
 	a.router.POST("/admin/registry/update/:name", a.registryUpdate)
 	a.router.GET("/admin/registry/update/:name", a.registryUpdateView)
+	a.router.GET("/admin/registry/update-preflight/:name", a.updatePreflight, router.SkipCSP)
 	a.router.POST("/admin/registry/trembita-client/:name", a.setTrembitaClientRegistryData)
 	a.router.POST("/admin/registry/trembita-client-create/:name", a.createTrembitaClientRegistry)
 	a.router.GET("/admin/registry/trembita-client-check/:name", a.checkTrembitaClientExists)
-	a.router.GET("/admin/registry/trembita-client-delete/:name", a.deleteTrembitaClient)
+	a.router.POST("/admin/registry/trembita-client-delete/:name", a.deleteTrembitaClient)
 	a.router.POST("/admin/registry/external-system/:name", a.setExternalSystemRegistryData)
 	a.router.POST("/admin/registry/external-system-create/:name", a.createExternalSystemRegistry)
 	a.router.GET("/admin/registry/external-system-check/:name", a.checkExternalSystemExists)
-	a.router.GET("/admin/registry/external-system-delete/:name", a.deleteExternalSystem)
+	a.router.POST("/admin/registry/external-system-delete/:name", a.deleteExternalSystem)
 
 	a.router.POST("/admin/registry/external-reg-add/:name", a.addExternalReg)
 	a.router.POST("/admin/registry/external-reg-remove/:name", a.removeExternalReg)
 	a.router.POST("/admin/registry/external-reg-disable/:name", a.disableExternalReg)
 
 	a.router.GET("/admin/change/:change", a.viewChange)
-	a.router.GET("/admin/submit-change/:change", a.submitChange)
-	a.router.GET("/admin/abandon-change/:change", a.abandonChange)
+	a.router.POST("/admin/submit-change/:change", a.submitChange)
+	a.router.POST("/admin/abandon-change/:change", a.abandonChange)
 
-	a.router.GET("/admin/registry/preload-values", a.preloadTemplateValues)
-	a.router.GET("/admin/registry/get-basic-username/:name", a.getBasicUsername)
+	a.router.GET("/admin/registry/preload-values", a.preloadTemplateValues, router.SkipCSP)
+	a.router.GET("/admin/registry/get-basic-username/:name", a.getBasicUsername, router.SkipCSP)
 
 	a.router.POST("/admin/registry/public-api-add/:name", a.addPublicAPIReg)
 	a.router.POST("/admin/registry/public-api-edit/:name", a.editPublicAPIReg)
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// RuntimeConfig is a part of configuration which can be reloaded without restart of console
+type RuntimeConfig struct {
+	VersionFilter   *VersionFilter
+	Timezone        string
+	CodebaseLabels  map[string]string
+	CurrentVersion  string
+	PreviousVersion string
+}
+
+// Runtime holds current runtime config, config is replaced as a whole,
+// so readers always get consistent values and must not modify them
+type Runtime struct {
+	current atomic.Pointer[RuntimeConfig]
+}
+
+func MakeRuntime(cnf *RuntimeConfig) *Runtime {
+	r := Runtime{}
+	r.current.Store(cnf)
+
+	return &r
+}
+
+func (r *Runtime) Load() *RuntimeConfig {
+	return r.current.Load()
+}
+
+func (r *Runtime) Store(cnf *RuntimeConfig) {
+	r.current.Store(cnf)
+}
+
+// ParseCodebaseLabels parses comma separated list of name=value pairs, malformed pairs are skipped
+func ParseCodebaseLabels(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+
+	labels := make(map[string]string)
+
+	for _, l := range strings.Split(value, ",") {
+		labelParts := strings.Split(l, "=")
+		if len(labelParts) == 2 {
+			labels[labelParts[0]] = labelParts[1]
+		}
+	}
+
+	return labels
+}
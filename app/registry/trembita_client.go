@@ -82,7 +82,7 @@ func (a *App) setTrembitaClientRegistryData(ctx *gin.Context) (rsp router.Respon
 		return nil, errors.Wrap(err, "unable to parse form")
 	}
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -128,7 +128,7 @@ func (a *App) setTrembitaClientRegistryData(ctx *gin.Context) (rsp router.Respon
 	trembitaDict[trembitaRegistriesKey] = registriesDict
 	values.OriginalYaml[trembitaValuesKey] = trembitaDict
 
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit,
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider,
 		[]string{}, MRLabel{Key: MRLabelTarget, Value: MRLabelTargetTrembitaRegistryUpdate},
 		MRLabel{Key: MRLabelTrembitaRegsitryName, Value: tf.TrembitaClientRegitryName}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
@@ -149,7 +149,7 @@ func (a *App) createTrembitaClientRegistry(ctx *gin.Context) (rsp router.Respons
 	if err := ctx.ShouldBind(&tf); err != nil {
 		return nil, errors.Wrap(err, "unable to parse form")
 	}
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -183,7 +183,7 @@ func (a *App) createTrembitaClientRegistry(ctx *gin.Context) (rsp router.Respons
 	}
 	values.Trembita.Registries[tf.TrembitaClientRegitryName] = trembitaRegistry
 	values.OriginalYaml[trembitaValuesKey] = values.Trembita
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit,
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider,
 		[]string{}, MRLabel{Key: MRLabelTarget, Value: MRLabelTargetTrembitaRegistryUpdate},
 		MRLabel{Key: MRLabelTrembitaRegsitryName, Value: tf.TrembitaClientRegitryName}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
@@ -200,9 +200,9 @@ func (a *App) deleteTrembitaClient(ctx *gin.Context) (rsp router.Response, retEr
 		return nil, errors.Wrap(err, "unable to find registry")
 	}
 
-	trembitaClientName := ctx.Query("trembita-client")
+	trembitaClientName := ctx.PostForm("trembita-client")
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
@@ -218,7 +218,7 @@ func (a *App) deleteTrembitaClient(ctx *gin.Context) (rsp router.Response, retEr
 
 	delete(values.Trembita.Registries, trembitaClientName)
 	values.OriginalYaml[trembitaValuesKey] = values.Trembita
-	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, []string{}); err != nil {
+	if err := CreateEditMergeRequest(ctx, registryName, values.OriginalYaml, a.Gerrit, a.GitProvider, []string{}); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
 	}
 
@@ -236,7 +236,7 @@ func (a *App) checkTrembitaClientExists(ctx *gin.Context) (rsp router.Response,
 
 	trembitaClientName := ctx.Query("trembita-client")
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values")
 	}
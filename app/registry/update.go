@@ -48,6 +48,7 @@ func (a *App) registryUpdateView(ctx *gin.Context) (router.Response, error) {
 	templateArgs, err := json.Marshal(gin.H{
 		"updateBranches": branches,
 		"registry":       reg,
+		"preflightError": ctx.GetString(updatePreflightErrorKey),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to encode template arguments")
@@ -91,6 +92,22 @@ func (a *App) registryUpdate(ctx *gin.Context) (router.Response, error) {
 			gin.H{"page": "registry", "errorsMap": validationErrors, "registry": r, "model": r}), nil
 	}
 
+	preflight, err := a.buildUpdatePreflight(userCtx, cb, ur.Branch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build update preflight")
+	}
+
+	if preflight.UpdateInProgress {
+		ctx.Set(updatePreflightErrorKey, "registry update is already in progress")
+		return a.registryUpdateView(ctx)
+	}
+
+	if preflight.Blocked() {
+		ctx.Set(updatePreflightErrorKey, fmt.Sprintf("target version %s is newer than platform version %s",
+			ur.Branch, preflight.PlatformVersion))
+		return a.registryUpdateView(ctx)
+	}
+
 	if err := a.createMergeRequest(cb, ur.Branch, userCtx, ctx); err != nil {
 		return nil, errors.Wrap(err, "unable to create merge request")
 	}
@@ -49,7 +49,8 @@ func HasUpdate(ctx context.Context, gerritService gerrit.ServiceInterface, cb *c
 		registryVersion = LowestVersion(branches)
 	}
 
-	mrs, err := gerritService.GetMergeRequestByProject(ctx, gerritProject.Spec.Name)
+	mrs, err := gerritService.FindMergeRequests(ctx, gerrit.MergeRequestFilter{Project: gerritProject.Spec.Name,
+		Target: mrTarget})
 	if err != nil {
 		return false, branches, nil, errors.Wrap(err, "unable to get merge requests")
 	}
@@ -61,10 +62,6 @@ func HasUpdate(ctx context.Context, gerritService gerrit.ServiceInterface, cb *c
 
 	hasOpenMR := false
 	for _, mr := range mrs {
-		if mr.Labels[MRLabelTarget] != mrTarget {
-			continue
-		}
-
 		if mr.Status.Value == gerrit.StatusNew {
 			hasOpenMR = true
 		}
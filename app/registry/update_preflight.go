@@ -0,0 +1,371 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
+	"ddm-admin-console/service/migration"
+	"ddm-admin-console/service/valuesdoc"
+)
+
+const updatePreflightErrorKey = "update-preflight-error"
+
+type SupersededMR struct {
+	Name      string `json:"name"`
+	Target    string `json:"target"`
+	ChangeURL string `json:"changeUrl"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// UpdatePreflight is a compatibility report of registry update, built before update merge request is created
+type UpdatePreflight struct {
+	Branch                 string                 `json:"branch"`
+	RegistryVersion        string                 `json:"registryVersion"`
+	PlatformVersion        string                 `json:"platformVersion"`
+	PlatformVersionExceeds bool                   `json:"platformVersionExceeds"`
+	UpdateInProgress       bool                   `json:"updateInProgress"`
+	Values                 string                 `json:"values"`
+	RemovedKeys            []string               `json:"removedKeys"`
+	MissingRequiredKeys    []string               `json:"missingRequiredKeys"`
+	Migrations             []migration.StepReport `json:"migrations"`
+	SupersededMRs          []SupersededMR         `json:"supersededMRs"`
+}
+
+// Blocked reports whether update must not be started
+func (p *UpdatePreflight) Blocked() bool {
+	return p.PlatformVersionExceeds || p.UpdateInProgress
+}
+
+type updatePreflightInput struct {
+	Branch          string
+	RegistryVersion *version.Version
+	PlatformVersion string
+	CurrentValues   map[string]any
+	TargetValues    map[string]any
+	TargetSchema    []byte
+	MergeRequests   []gerrit.GerritMergeRequest
+	Migrations      migration.Migrations
+}
+
+func (a *App) updatePreflight(ctx *gin.Context) (router.Response, error) {
+	userCtx := router.ContextWithUserAccessToken(ctx)
+	cbService, err := a.Services.Codebase.ServiceForContext(userCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init service for user context")
+	}
+
+	cb, err := cbService.Get(ctx.Param("name"))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get registry")
+	}
+
+	branch := ctx.Query("branch")
+	if branch == "" {
+		return router.MakeJSONResponse(http.StatusUnprocessableEntity, gin.H{"error": "branch is required"}), nil
+	}
+
+	report, err := a.buildUpdatePreflight(userCtx, cb, branch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build update preflight")
+	}
+
+	return router.MakeJSONResponse(http.StatusOK, report), nil
+}
+
+func (a *App) buildUpdatePreflight(ctx context.Context, cb *codebase.Codebase, branch string) (*UpdatePreflight, error) {
+	_, branches, registryVersion, err := HasUpdate(ctx, a.Services.Gerrit, cb, MRTargetRegistryVersionUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to check for updates")
+	}
+
+	mrs, err := a.Services.Gerrit.GetMergeRequestByProject(ctx, cb.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get merge requests")
+	}
+
+	if hasOpenUpdateMR(mrs) {
+		return inProgressUpdatePreflight(branch, registryVersion), nil
+	}
+
+	found := false
+	for _, br := range branches {
+		if br == branch {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, errors.Errorf("%s is not available for update", branch)
+	}
+
+	currentValues, err := rawValuesFromGit(a.Services.GitProvider, cb.Name, cb.Spec.DefaultBranch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get current values")
+	}
+
+	targetValues, err := rawValuesFromGit(a.Services.GitProvider, cb.Name, branch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get target values")
+	}
+
+	schema, err := LoadValuesSchema(a.Services.GitProvider, cb.Name, branch, RegistryValuesSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load target values schema")
+	}
+
+	return makeUpdatePreflight(updatePreflightInput{
+		Branch:          branch,
+		RegistryVersion: registryVersion,
+		PlatformVersion: a.Runtime.Load().CurrentVersion,
+		CurrentValues:   currentValues,
+		TargetValues:    targetValues,
+		TargetSchema:    schema,
+		MergeRequests:   mrs,
+		Migrations:      migration.Registered,
+	})
+}
+
+func hasOpenUpdateMR(mrs []gerrit.GerritMergeRequest) bool {
+	for _, mr := range mrs {
+		if mr.Status.Value == gerrit.StatusNew && mr.Labels[MRLabelTarget] == MRTargetRegistryVersionUpdate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inProgressUpdatePreflight reports that registry update merge request is already open and new update
+// can not be started until it is merged or abandoned
+func inProgressUpdatePreflight(branch string, registryVersion *version.Version) *UpdatePreflight {
+	report := UpdatePreflight{
+		Branch:              branch,
+		UpdateInProgress:    true,
+		RemovedKeys:         []string{},
+		MissingRequiredKeys: []string{},
+		Migrations:          []migration.StepReport{},
+		SupersededMRs:       []SupersededMR{},
+	}
+
+	if registryVersion != nil {
+		report.RegistryVersion = registryVersion.Original()
+	}
+
+	return &report
+}
+
+// makeUpdatePreflight simulates values merge of update merge request controller and checks its result
+func makeUpdatePreflight(in updatePreflightInput) (*UpdatePreflight, error) {
+	targetVersion := BranchVersion(in.Branch)
+	report := UpdatePreflight{
+		Branch:              in.Branch,
+		PlatformVersion:     in.PlatformVersion,
+		RemovedKeys:         []string{},
+		MissingRequiredKeys: []string{},
+		Migrations:          []migration.StepReport{},
+		SupersededMRs:       []SupersededMR{},
+	}
+
+	if in.RegistryVersion != nil {
+		report.RegistryVersion = in.RegistryVersion.Original()
+	}
+
+	if in.PlatformVersion != "" {
+		platformVersion, err := version.NewVersion(in.PlatformVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse platform version")
+		}
+
+		report.PlatformVersionExceeds = platformVersion.LessThan(targetVersion)
+	}
+
+	// migrations modify merged values in place, target values are kept intact to compare with
+	merged := valuesdoc.MergeMaps(copyValues(in.TargetValues), in.CurrentValues)
+
+	if in.RegistryVersion != nil {
+		migrationReport, err := in.Migrations.Apply(merged, in.RegistryVersion, targetVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to apply values migrations")
+		}
+
+		report.Migrations = append(report.Migrations, migrationReport.Steps...)
+	}
+
+	mergedYaml, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode merged values")
+	}
+
+	report.Values = string(mergedYaml)
+
+	for _, p := range valuesLeafPaths(merged, "") {
+		if !valuesPathExists(in.TargetValues, p) {
+			report.RemovedKeys = append(report.RemovedKeys, p)
+		}
+	}
+
+	if len(in.TargetSchema) > 0 {
+		missing, err := missingRequiredValues(in.TargetSchema, merged)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to check required values")
+		}
+
+		report.MissingRequiredKeys = append(report.MissingRequiredKeys, missing...)
+	}
+
+	for _, mr := range in.MergeRequests {
+		if mr.Status.Value != gerrit.StatusNew {
+			continue
+		}
+
+		report.SupersededMRs = append(report.SupersededMRs, SupersededMR{
+			Name:      mr.Name,
+			Target:    mr.Labels[MRLabelTarget],
+			ChangeURL: mr.Status.ChangeURL,
+			CreatedAt: mr.FormattedCreatedAt(),
+		})
+	}
+
+	return &report, nil
+}
+
+func rawValuesFromGit(provider gitprovider.Provider, projectName, branch string) (map[string]any, error) {
+	content, err := provider.GetFile(context.Background(), projectName, branch, ValuesLocation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get values file, %w", err)
+	}
+
+	values := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(content), &values); err != nil {
+		return nil, fmt.Errorf("unable to decode values, %w", err)
+	}
+
+	return values, nil
+}
+
+func copyValues(values map[string]any) map[string]any {
+	out := make(map[string]any, len(values))
+	for k, v := range values {
+		if m, ok := v.(map[string]any); ok {
+			out[k] = copyValues(m)
+			continue
+		}
+
+		out[k] = v
+	}
+
+	return out
+}
+
+func valuesLeafPaths(values map[string]any, prefix string) []string {
+	var paths []string
+	for k, v := range values {
+		p := joinValuesPath(prefix, k)
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			paths = append(paths, valuesLeafPaths(m, p)...)
+			continue
+		}
+
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func valuesPathExists(values map[string]any, path string) bool {
+	_, ok := lookupValuesPath(values, path)
+	return ok
+}
+
+func lookupValuesPath(values map[string]any, path string) (any, bool) {
+	var current any = values
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// missingRequiredValues returns paths of values required by schema which are absent in values,
+// only objects present in values or required themselves are checked
+func missingRequiredValues(schema []byte, values map[string]any) ([]string, error) {
+	var schemaDoc map[string]any
+	if err := json.Unmarshal(schema, &schemaDoc); err != nil {
+		return nil, fmt.Errorf("unable to decode values schema, %w", err)
+	}
+
+	inlined, err := inlineSchemaRefs(schemaDoc, schemaDoc, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve values schema references, %w", err)
+	}
+
+	missing := collectMissingRequired(inlined, values, "")
+	sort.Strings(missing)
+
+	return missing, nil
+}
+
+func collectMissingRequired(schemaNode any, value any, prefix string) []string {
+	node, ok := schemaNode.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	values, _ := value.(map[string]any)
+	properties, _ := node["properties"].(map[string]any)
+	required, _ := node["required"].([]any)
+
+	var missing []string
+	for _, r := range required {
+		name, ok := r.(string)
+		if !ok {
+			continue
+		}
+
+		if _, ok := values[name]; !ok {
+			missing = append(missing, joinValuesPath(prefix, name))
+		}
+	}
+
+	for name, propSchema := range properties {
+		v, ok := values[name]
+		if !ok {
+			continue
+		}
+
+		missing = append(missing, collectMissingRequired(propSchema, v, joinValuesPath(prefix, name))...)
+	}
+
+	return missing
+}
+
+func joinValuesPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/migration"
+)
+
+func TestMakeUpdatePreflight(t *testing.T) {
+	t.Parallel()
+
+	targetValues := map[string]any{
+		"global": map[string]any{
+			"deploymentMode": "development",
+			"registry": map[string]any{
+				"bpmsEngine": map[string]any{"replicas": 1},
+			},
+		},
+	}
+
+	report, err := makeUpdatePreflight(updatePreflightInput{
+		Branch:          "1.9.7",
+		RegistryVersion: version.Must(version.NewVersion("1.9.6")),
+		PlatformVersion: "1.9.6",
+		CurrentValues: map[string]any{
+			"global": map[string]any{
+				"deploymentMode": "production",
+				"registry": map[string]any{
+					"bpms": map[string]any{"replicas": 3},
+				},
+				"legacy": true,
+			},
+		},
+		TargetValues: targetValues,
+		TargetSchema: []byte(`{
+			"type": "object",
+			"required": ["global", "keycloak"],
+			"properties": {
+				"global": {"$ref": "#/definitions/global"}
+			},
+			"definitions": {
+				"global": {"type": "object", "required": ["deploymentMode", "language"]}
+			}
+		}`),
+		MergeRequests: []gerrit.GerritMergeRequest{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "edit", Labels: map[string]string{MRLabelTarget: "registry-edit"}},
+				Status:     gerrit.GerritMergeRequestStatus{Value: gerrit.StatusNew},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "merged"},
+				Status:     gerrit.GerritMergeRequestStatus{Value: gerrit.StatusMerged},
+			},
+		},
+		Migrations: migration.Migrations{
+			{
+				Version: version.Must(version.NewVersion("1.9.7")),
+				Steps:   []migration.Step{migration.Rename("global.registry.bpms", "bpmsEngine")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.True(t, report.Blocked())
+	require.Equal(t, "1.9.6", report.RegistryVersion)
+	require.Equal(t, []string{"global.legacy"}, report.RemovedKeys)
+	require.Equal(t, []string{"global.language", "keycloak"}, report.MissingRequiredKeys)
+	require.Len(t, report.Migrations, 1)
+	require.Len(t, report.SupersededMRs, 1)
+	require.Equal(t, "edit", report.SupersededMRs[0].Name)
+	require.Contains(t, report.Values, "deploymentMode: production")
+	require.Contains(t, report.Values, "replicas: 3")
+	require.Equal(t, map[string]any{"replicas": 1},
+		targetValues["global"].(map[string]any)["registry"].(map[string]any)["bpmsEngine"])
+}
+
+func TestInProgressUpdatePreflight(t *testing.T) {
+	t.Parallel()
+
+	mrs := []gerrit.GerritMergeRequest{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{MRLabelTarget: mrTargetEditRegistry}},
+			Status:     gerrit.GerritMergeRequestStatus{Value: gerrit.StatusNew},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{MRLabelTarget: MRTargetRegistryVersionUpdate}},
+			Status:     gerrit.GerritMergeRequestStatus{Value: gerrit.StatusMerged},
+		},
+	}
+	require.False(t, hasOpenUpdateMR(mrs))
+
+	mrs[1].Status.Value = gerrit.StatusNew
+	require.True(t, hasOpenUpdateMR(mrs))
+
+	report := inProgressUpdatePreflight("1.9.7", version.Must(version.NewVersion("1.9.6")))
+	require.True(t, report.UpdateInProgress)
+	require.True(t, report.Blocked())
+	require.Equal(t, "1.9.6", report.RegistryVersion)
+	require.Empty(t, report.SupersededMRs)
+}
@@ -1,8 +1,12 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+
+	"ddm-admin-console/service/gitprovider"
+	"ddm-admin-console/service/valuesdoc"
 )
 
 const (
@@ -309,3 +313,19 @@ type ClusterKeycloak struct {
 type CustomHost struct {
 	Host string `json:"host" yaml:"host"`
 }
+
+// EncodeProjectValues encodes values as a patch of project values.yaml from branch, so comments, key order
+// and formatting of the unchanged parts of the file are kept
+func EncodeProjectValues(provider gitprovider.Provider, projectName, branch string, values map[string]any) (string, error) {
+	current, err := provider.GetFile(context.Background(), projectName, branch, ValuesLocation)
+	if err != nil {
+		return "", fmt.Errorf("unable to get values file, %w", err)
+	}
+
+	bts, err := valuesdoc.Apply([]byte(current), values)
+	if err != nil {
+		return "", fmt.Errorf("unable to patch values yaml, %w", err)
+	}
+
+	return string(bts), nil
+}
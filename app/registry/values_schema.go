@@ -0,0 +1,202 @@
+package registry
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	openAPIErrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/gitprovider"
+)
+
+const (
+	// ValuesSchemaLocation is a helm values schema shipped together with values.yaml
+	ValuesSchemaLocation = "deploy-templates/values.schema.json"
+	RegistryValuesSchema = "registry"
+	ClusterValuesSchema  = "cluster"
+	ValuesSchemaErrorTag = "values-schema"
+	valuesErrorsKey      = "values-errors"
+	maxSchemaRefDepth    = 32
+)
+
+//go:embed schema/*.json
+var embeddedValuesSchemas embed.FS
+
+// LoadValuesSchema loads values schema from project branch, embedded schema of current platform version
+// is used when project does not ship one
+func LoadValuesSchema(provider gitprovider.Provider, projectName, branch, fallback string) ([]byte, error) {
+	content, err := provider.GetFile(context.Background(), projectName, branch, ValuesSchemaLocation)
+	if err == nil && strings.TrimSpace(content) != "" {
+		return []byte(content), nil
+	}
+
+	if err != nil && !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, fmt.Errorf("unable to get values schema, %w", err)
+	}
+
+	bts, err := embeddedValuesSchemas.ReadFile(fmt.Sprintf("schema/%s.json", fallback))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read embedded values schema, %w", err)
+	}
+
+	return bts, nil
+}
+
+// ValidateValues validates values against json schema, returns validator.ValidationErrors with
+// field errors keyed by values path
+func ValidateValues(schema []byte, values map[string]any) error {
+	var schemaDoc map[string]any
+	if err := json.Unmarshal(schema, &schemaDoc); err != nil {
+		return fmt.Errorf("unable to decode values schema, %w", err)
+	}
+
+	// validator does not resolve references, so local definitions are inlined
+	inlined, err := inlineSchemaRefs(schemaDoc, schemaDoc, 0)
+	if err != nil {
+		return fmt.Errorf("unable to resolve values schema references, %w", err)
+	}
+
+	inlinedJSON, err := json.Marshal(inlined)
+	if err != nil {
+		return fmt.Errorf("unable to encode values schema, %w", err)
+	}
+
+	var sch spec.Schema
+	if err := json.Unmarshal(inlinedJSON, &sch); err != nil {
+		return fmt.Errorf("unable to decode values schema, %w", err)
+	}
+
+	// yaml decoded values may contain go types unknown to json schema validator
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("unable to encode values, %w", err)
+	}
+
+	var data any
+	if err := json.Unmarshal(valuesJSON, &data); err != nil {
+		return fmt.Errorf("unable to decode values, %w", err)
+	}
+
+	result := validate.NewSchemaValidator(&sch, nil, "", strfmt.Default).Validate(data)
+	if result.IsValid() {
+		return nil
+	}
+
+	fieldErrors := make([]validator.FieldError, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		field := ""
+		if ve, ok := e.(*openAPIErrors.Validation); ok {
+			field = ve.Name
+		}
+
+		fieldErrors = append(fieldErrors, router.MakeFieldErrorWithParam(field, ValuesSchemaErrorTag, e.Error()))
+	}
+
+	sort.Slice(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field() < fieldErrors[j].Field()
+	})
+
+	return validator.ValidationErrors(fieldErrors)
+}
+
+func inlineSchemaRefs(node any, root map[string]any, depth int) (any, error) {
+	if depth > maxSchemaRefDepth {
+		return nil, errors.New("schema references are too deep or recursive")
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok {
+			target, err := resolveSchemaRef(ref, root)
+			if err != nil {
+				return nil, err
+			}
+
+			return inlineSchemaRefs(target, root, depth+1)
+		}
+
+		res := make(map[string]any, len(n))
+		for k, v := range n {
+			if k == "definitions" {
+				continue
+			}
+
+			inlined, err := inlineSchemaRefs(v, root, depth)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = inlined
+		}
+
+		return res, nil
+	case []any:
+		res := make([]any, 0, len(n))
+		for _, v := range n {
+			inlined, err := inlineSchemaRefs(v, root, depth)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, inlined)
+		}
+
+		return res, nil
+	}
+
+	return node, nil
+}
+
+func resolveSchemaRef(ref string, root map[string]any) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("only local schema references are supported, got %s", ref)
+	}
+
+	var current any = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("schema reference %s not found", ref)
+		}
+
+		if current, ok = m[part]; !ok {
+			return nil, fmt.Errorf("schema reference %s not found", ref)
+		}
+	}
+
+	return current, nil
+}
+
+// ValidateProjectValues validates values against values schema of project branch, fallback schema is used
+// when the branch has no schema
+func ValidateProjectValues(provider gitprovider.Provider, projectName, branch, fallback string,
+	values map[string]any) error {
+	schema, err := LoadValuesSchema(provider, projectName, branch, fallback)
+	if err != nil {
+		return err
+	}
+
+	return ValidateValues(schema, values)
+}
+
+// ValuesSchemaMessages returns human readable messages of values schema validation errors
+func ValuesSchemaMessages(err error) []string {
+	var messages []string
+	for _, fe := range router.FieldErrors(err) {
+		if fe.Tag() == ValuesSchemaErrorTag {
+			messages = append(messages, fe.Param())
+		}
+	}
+
+	return messages
+}
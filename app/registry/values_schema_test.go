@@ -0,0 +1,66 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ddm-admin-console/router"
+)
+
+func TestValidateValues(t *testing.T) {
+	t.Parallel()
+
+	schema, err := embeddedValuesSchemas.ReadFile("schema/registry.json")
+	require.NoError(t, err)
+
+	err = ValidateValues(schema, map[string]any{
+		"global": map[string]any{
+			"excludePortals": []string{"officer"},
+			"computeResources": map[string]any{
+				"instanceCount": "2",
+			},
+			"registry": map[string]any{
+				"bpms": map[string]any{
+					"replicas": 1,
+					"container": map[string]any{
+						"resources": map[string]any{
+							"requests": map[string]any{"cpu": "500m", "memory": "1Gi"},
+						},
+					},
+				},
+			},
+		},
+		"unknown": "kept as is",
+	})
+	require.NoError(t, err)
+
+	err = ValidateValues(schema, map[string]any{
+		"global": map[string]any{
+			"excludePortals": []string{"unknown-portal"},
+			"computeResources": map[string]any{
+				"instanceCount": "two",
+				"wrongKey":      true,
+			},
+			"registry": map[string]any{
+				"bpms": map[string]any{"replicas": -1},
+			},
+		},
+	})
+	require.Error(t, err)
+
+	fieldErrors := router.FieldErrors(err)
+	require.NotEmpty(t, fieldErrors)
+
+	fields := make(map[string]bool)
+	for _, fe := range fieldErrors {
+		require.Equal(t, ValuesSchemaErrorTag, fe.Tag())
+		require.NotEmpty(t, fe.Param())
+		fields[fe.Field()] = true
+	}
+
+	require.True(t, fields["global.excludePortals[0]"])
+	require.True(t, fields["global.computeResources.instanceCount"])
+	require.True(t, fields["global.registry.bpms.replicas"])
+	require.Len(t, ValuesSchemaMessages(err), len(fieldErrors))
+}
@@ -2,85 +2,179 @@ package registry
 
 import (
 	"ddm-admin-console/service/codebase"
-	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-version"
 )
 
+// constraintRegexp matches operator and version with optional trailing wildcard segments, e.g. >=1.9.x
+var constraintRegexp = regexp.MustCompile(`^(==|!=|>=|<=|>|<|=)?\s*v?(\d+(?:\.\d+)*)((?:\.[xX*])*)$`)
+
+// VersionFilter selects registries of versions owned by console. Pattern is a list of alternatives separated
+// by "||", each alternative is a list of comma separated constraints which all must match, e.g.
+// ">=1.9.3, <1.10, !=1.9.5 || 1.11.x". Constraint compares only segments it specifies, so 1.9 and 1.9.x
+// match every 1.9 version, while 1.9.3 matches 1.9.3 with its builds and release candidates.
+// Operator is one of ==, =, !=, >, >=, <, <=, version without operator is an equality.
 type VersionFilter struct {
-	compareFunc func(o *version.Version) bool
+	pattern      string
+	alternatives [][]versionConstraint
+}
+
+type versionConstraint struct {
+	op       string
+	segments []int64
 }
 
 func MakeVersionFilter(pattern string) (*VersionFilter, error) {
-	if pattern == "" {
-		return &VersionFilter{}, nil
+	vf := VersionFilter{pattern: strings.TrimSpace(pattern)}
+	if vf.pattern == "" {
+		return &vf, nil
 	}
 
-	regPattern := regexp.MustCompile(`(<=|>=|==|>|<)(.+)`)
-	elements := regPattern.FindStringSubmatch(pattern)
-	if len(elements) < 3 {
-		return nil, errors.New("wrong input pattern")
+	for _, alt := range strings.Split(vf.pattern, "||") {
+		var constraints []versionConstraint
+
+		for _, term := range strings.Split(alt, ",") {
+			c, err := parseVersionConstraint(strings.TrimSpace(term))
+			if err != nil {
+				return nil, err
+			}
+
+			constraints = append(constraints, c)
+		}
+
+		vf.alternatives = append(vf.alternatives, constraints)
 	}
 
-	v, err := version.NewVersion(elements[2])
-	if err != nil {
-		return nil, fmt.Errorf("wrong version pattern, %w", err)
+	return &vf, nil
+}
+
+func parseVersionConstraint(term string) (versionConstraint, error) {
+	elements := constraintRegexp.FindStringSubmatch(term)
+	if elements == nil {
+		return versionConstraint{}, fmt.Errorf("wrong version constraint %q", term)
 	}
 
-	compareFunc, ok := compareFunctions(v)[elements[1]]
-	if !ok {
-		return nil, errors.New("wrong compare pattern")
+	c := versionConstraint{op: elements[1]}
+	switch c.op {
+	case "", "=":
+		c.op = "=="
 	}
 
-	return &VersionFilter{
-		compareFunc: compareFunc,
-	}, nil
-}
+	for _, s := range strings.Split(elements[2], ".") {
+		seg, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return versionConstraint{}, fmt.Errorf("wrong version constraint %q, %w", term, err)
+		}
 
-func compareFunctions(v *version.Version) map[string]func(o *version.Version) bool {
-	versionString := v.String()
+		c.segments = append(c.segments, seg)
+	}
 
-	equalFunc := func(other *version.Version) bool {
-		otherString := other.String()
+	return c, nil
+}
 
-		return strings.HasPrefix(otherString, versionString)
+func (c versionConstraint) check(v *version.Version) bool {
+	cmp := compareSegments(v.Segments64(), c.segments)
+
+	switch c.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp <= 0
 	}
+}
 
-	greaterFunc := func(other *version.Version) bool {
-		if equalFunc(other) {
-			return false
+// compareSegments compares leading segments of version with segments of constraint
+func compareSegments(segments, constraint []int64) int {
+	for i, want := range constraint {
+		var got int64
+		if i < len(segments) {
+			got = segments[i]
 		}
 
-		return v.GreaterThan(other)
-	}
+		if got != want {
+			if got < want {
+				return -1
+			}
 
-	lessFunc := func(other *version.Version) bool {
-		if equalFunc(other) {
-			return false
+			return 1
 		}
+	}
+
+	return 0
+}
+
+// String returns pattern filter is made from
+func (vf *VersionFilter) String() string {
+	return vf.pattern
+}
 
-		return v.LessThan(other)
+// Check reports if version matches the filter
+func (vf *VersionFilter) Check(v *version.Version) bool {
+	if len(vf.alternatives) == 0 {
+		return true
 	}
 
-	return map[string]func(o *version.Version) bool{
-		"==": equalFunc,
-		"<":  greaterFunc,
-		">":  lessFunc,
+	for _, constraints := range vf.alternatives {
+		matched := true
+
+		for _, c := range constraints {
+			if !c.check(v) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
 	}
+
+	return false
 }
 
 // CheckCodebase checks if codebase version matches the filter.
 func (vf *VersionFilter) CheckCodebase(cb *codebase.Codebase) bool {
-	if vf.compareFunc == nil {
+	if len(vf.alternatives) == 0 {
 		return true
 	}
 
-	if cb.Version == nil {
-		return false
+	v := cb.Version
+	if v == nil {
+		stored, ok := cb.StoredVersion()
+		if !ok {
+			return false
+		}
+
+		v = stored
+	}
+
+	return vf.Check(v)
+}
+
+// FilterCodebases returns codebases which versions match the filter
+func (vf *VersionFilter) FilterCodebases(cbs []codebase.Codebase) []codebase.Codebase {
+	if len(vf.alternatives) == 0 {
+		return cbs
+	}
+
+	filtered := make([]codebase.Codebase, 0, len(cbs))
+	for i := range cbs {
+		if vf.CheckCodebase(&cbs[i]) {
+			filtered = append(filtered, cbs[i])
+		}
 	}
 
-	return vf.compareFunc(cb.Version)
+	return filtered
 }
@@ -43,6 +43,26 @@ func TestVersionFilterMake(t *testing.T) {
 			version: "<1.9.2",
 			wantErr: require.NoError,
 		},
+		{
+			name:    "range with exclusion and alternative",
+			version: ">=1.9.3, <=1.10 , !=1.9.5 || 1.11.x",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "unknown operator",
+			version: "~1.9",
+			wantErr: require.Error,
+		},
+		{
+			name:    "empty constraint",
+			version: ">=1.9.3,",
+			wantErr: require.Error,
+		},
+		{
+			name:    "wildcard in the middle",
+			version: "1.x.1",
+			wantErr: require.Error,
+		},
 	}
 
 	for _, tt := range tests {
@@ -132,6 +152,66 @@ func TestVersionFilter_CheckCodebase(t *testing.T) {
 			codebaseVersion: "1.1.0.123",
 			want:            false,
 		},
+		{
+			name:            "equal segments only",
+			filterVersion:   "==1.1",
+			codebaseVersion: "1.10.0",
+			want:            false,
+		},
+		{
+			name:            "less or equal",
+			filterVersion:   "<=1.9.3",
+			codebaseVersion: "1.9.3",
+			want:            true,
+		},
+		{
+			name:            "greater or equal",
+			filterVersion:   ">=1.9.3",
+			codebaseVersion: "1.9.2",
+			want:            false,
+		},
+		{
+			name:            "in range",
+			filterVersion:   ">=1.9.3, <1.10",
+			codebaseVersion: "1.9.12",
+			want:            true,
+		},
+		{
+			name:            "out of range",
+			filterVersion:   ">=1.9.3, <1.10",
+			codebaseVersion: "1.10.0",
+			want:            false,
+		},
+		{
+			name:            "excluded from range",
+			filterVersion:   ">=1.9.3, <1.10, !=1.9.5",
+			codebaseVersion: "1.9.5.17",
+			want:            false,
+		},
+		{
+			name:            "wildcard",
+			filterVersion:   "1.9.x",
+			codebaseVersion: "1.9.7",
+			want:            true,
+		},
+		{
+			name:            "greater than wildcard",
+			filterVersion:   ">1.9.*",
+			codebaseVersion: "1.9.7",
+			want:            false,
+		},
+		{
+			name:            "second alternative",
+			filterVersion:   "<1.9 || 1.11.x",
+			codebaseVersion: "1.11.2",
+			want:            true,
+		},
+		{
+			name:            "no alternative",
+			filterVersion:   "<1.9 || 1.11.x",
+			codebaseVersion: "1.10.2",
+			want:            false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"ddm-admin-console/router"
@@ -17,6 +18,7 @@ import (
 	edpcomponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
 	"ddm-admin-console/service/jenkins"
+	"ddm-admin-console/tracing"
 )
 
 func (a *App) viewRegistry(ctx *gin.Context) (router.Response, error) {
@@ -24,7 +26,7 @@ func (a *App) viewRegistry(ctx *gin.Context) (router.Response, error) {
 
 	registryName := ctx.Param("name")
 
-	values, err := GetValuesFromGit(registryName, MasterBranch, a.Services.Gerrit)
+	values, err := GetValuesFromGit(registryName, MasterBranch, a.Services.GitProvider)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get values from git")
 	}
@@ -35,7 +37,7 @@ func (a *App) viewRegistry(ctx *gin.Context) (router.Response, error) {
 	}
 
 	viewParams := gin.H{
-		"timezone":   a.Config.Timezone,
+		"timezone":   a.Runtime.Load().Timezone,
 		"values":     values,
 		"valuesJson": string(valuesJson),
 	}
@@ -66,9 +68,14 @@ func (a *App) viewRegistry(ctx *gin.Context) (router.Response, error) {
 
 	viewParams["hasUpdate"] = hasUpdate
 
-	for _, f := range a.viewRegistryProcessFunctions(mrs) {
-		if err := f(userCtx, reg, values, viewParams); err != nil {
-			return nil, errors.Wrap(err, "error during view registry function")
+	for _, step := range a.viewRegistryProcessFunctions(mrs) {
+		stepCtx, span := tracing.Start(userCtx, "registry.view."+step.name,
+			attribute.String("registry", reg.Name))
+		err := step.process(stepCtx, reg, values, viewParams)
+		tracing.End(span, err)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "error during view registry function %s", step.name)
 		}
 	}
 
@@ -84,27 +91,28 @@ func (a *App) viewRegistry(ctx *gin.Context) (router.Response, error) {
 	}), nil
 }
 
-func (a *App) viewRegistryProcessFunctions(
-	mrs []gerrit.GerritMergeRequest,
-) []func(
-	ctx context.Context,
-	reg *codebase.Codebase,
-	values *Values,
-	viewParams gin.H,
-) error {
-	return []func(ctx context.Context, reg *codebase.Codebase, values *Values, viewParams gin.H) error{ // TODO: this is confusing and forces the functions to have the same signature, call the functions directly.
-		a.viewRegistryAllowedToEdit,
-		a.viewRegistryGetRegistryAndBranches,
-		a.viewRegistryGetEDPComponents,
-		a.makeViewRegistryGetMergeRequests(mrs),
-		a.makeViewRegistryExternalRegistration(mrs),
-		a.makeViewRegistryPublicAPI(mrs),
-		a.viewDNSConfig,
-		a.viewCIDRConfig,
-		a.viewAdministratorsConfig,
-		a.viewUpdateTrembitaRegistries,
-		a.viewGetMasterJobStatus,
-		a.viewCreateReleaseJobAvailable,
+type viewRegistryProcessFunc func(ctx context.Context, reg *codebase.Codebase, values *Values, viewParams gin.H) error
+
+// viewRegistryStep is a named step of registry view, name is used as span name
+type viewRegistryStep struct {
+	name    string
+	process viewRegistryProcessFunc
+}
+
+func (a *App) viewRegistryProcessFunctions(mrs []gerrit.GerritMergeRequest) []viewRegistryStep {
+	return []viewRegistryStep{ // TODO: this is confusing and forces the functions to have the same signature, call the functions directly.
+		{name: "allowed-to-edit", process: a.viewRegistryAllowedToEdit},
+		{name: "registry-and-branches", process: a.viewRegistryGetRegistryAndBranches},
+		{name: "edp-components", process: a.viewRegistryGetEDPComponents},
+		{name: "merge-requests", process: a.makeViewRegistryGetMergeRequests(mrs)},
+		{name: "external-registration", process: a.makeViewRegistryExternalRegistration(mrs)},
+		{name: "public-api", process: a.makeViewRegistryPublicAPI(mrs)},
+		{name: "dns-config", process: a.viewDNSConfig},
+		{name: "cidr-config", process: a.viewCIDRConfig},
+		{name: "administrators-config", process: a.viewAdministratorsConfig},
+		{name: "trembita-registries", process: a.viewUpdateTrembitaRegistries},
+		{name: "master-job-status", process: a.viewGetMasterJobStatus},
+		{name: "create-release-job", process: a.viewCreateReleaseJobAvailable},
 	}
 }
 
@@ -425,7 +433,7 @@ func (a *App) viewRegistryGetRegistryAndBranches(userCtx context.Context, reg *c
 
 	viewParams["registry"] = reg
 	viewParams["branches"] = branches
-	viewParams["created"] = reg.FormattedCreatedAtTimezone(a.Config.Timezone)
+	viewParams["created"] = reg.FormattedCreatedAtTimezone(a.Runtime.Load().Timezone)
 
 	return nil
 }
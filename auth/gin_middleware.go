@@ -4,15 +4,19 @@ import (
 	"ddm-admin-console/app/dashboard"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/oauth2"
 )
 
+// refreshBefore makes tokens refreshed slightly before expiry, so they do not expire during request
+const refreshBefore = time.Minute
+
 func MakeGinMiddleware(o dashboard.OAuth, tokenSessionKey, tokenValidSessionKey, filterPath string) func(ctx *gin.Context) {
 	return func(ctx *gin.Context) {
-		tokenValid := tokenIsValid(ctx, tokenSessionKey)
+		tokenValid := tokenIsValid(ctx, o, tokenSessionKey)
 		ctx.Set(tokenValidSessionKey, tokenValid)
 
 		if !strings.Contains(ctx.Request.RequestURI, filterPath) {
@@ -29,7 +33,7 @@ func MakeGinMiddleware(o dashboard.OAuth, tokenSessionKey, tokenValidSessionKey,
 	}
 }
 
-func tokenIsValid(ctx *gin.Context, tokenSessionKey string) bool {
+func tokenIsValid(ctx *gin.Context, o dashboard.OAuth, tokenSessionKey string) bool {
 	session := sessions.Default(ctx)
 	tsRaw := session.Get(tokenSessionKey)
 	if tsRaw == nil {
@@ -41,7 +45,21 @@ func tokenIsValid(ctx *gin.Context, tokenSessionKey string) bool {
 		return false
 	}
 
-	return token.Valid()
+	if token.RefreshToken == "" || token.Expiry.IsZero() || time.Until(token.Expiry) > refreshBefore {
+		return token.Valid()
+	}
+
+	refreshed, err := o.RefreshToken(ctx, token)
+	if err != nil {
+		return token.Valid()
+	}
+
+	session.Set(tokenSessionKey, refreshed)
+	if err := session.Save(); err != nil {
+		return token.Valid()
+	}
+
+	return refreshed.Valid()
 }
 
 func ginStartAuth(o dashboard.OAuth, ctx *gin.Context) {
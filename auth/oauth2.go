@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/tracing"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -111,9 +112,9 @@ func (o *OAuth2) UseInternalTokenService(ctx context.Context, serviceHost string
 	if !caCertPool.AppendCertsFromPEM([]byte(ca)) {
 		return errors.New("unable to append certs from PEM")
 	}
-	o.httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{
+	o.httpClient.Transport = tracing.Transport(&http.Transport{TLSClientConfig: &tls.Config{
 		RootCAs: caCertPool,
-	}}
+	}})
 
 	return nil
 }
@@ -136,6 +137,26 @@ func (o *OAuth2) GetTokenClient(ctx context.Context, code string) (token *oauth2
 	return
 }
 
+// RefreshToken exchanges refresh token of expired token for new access token
+func (o *OAuth2) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.New("token has no refresh token")
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, o.httpClient)
+
+	// expiry is reset to make token source refresh token even if it is still valid
+	expired := *token
+	expired.Expiry = time.Unix(1, 0)
+
+	refreshed, err := o.Config.TokenSource(ctx, &expired).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to refresh access token")
+	}
+
+	return refreshed, nil
+}
+
 func (o *OAuth2) GetHTTPClient(ctx context.Context, token *oauth2.Token) *http.Client {
 	return o.Config.Client(ctx, token)
 }
@@ -0,0 +1,14 @@
+package config
+
+import "ddm-admin-console/resilience"
+
+// ClientPolicy is applied to calls of gerrit, jenkins and vault clients
+func (cnf *Settings) ClientPolicy() resilience.Policy {
+	return resilience.Policy{
+		Timeout:          cnf.ClientTimeout,
+		Retries:          cnf.ClientRetries,
+		Backoff:          cnf.ClientRetryBackoff,
+		BreakerThreshold: cnf.ClientBreakerThreshold,
+		BreakerCooldown:  cnf.ClientBreakerCooldown,
+	}
+}
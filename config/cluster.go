@@ -8,8 +8,10 @@ func (s *Services) ClusterServices() cluster.Services {
 		K8S:          s.K8S,
 		EDPComponent: s.EDPComponent,
 		Gerrit:       s.Gerrit,
+		GitProvider:  s.GitProvider,
 		Jenkins:      s.Jenkins,
 		Vault:        s.Vault,
+		Keycloak:     s.Keycloak,
 	}
 }
 
@@ -26,6 +28,8 @@ func (cnf *Settings) ClusterConfig() cluster.Config {
 		TempFolder:                    cnf.TempFolder,
 		KeycloakDefaultHostname:       cnf.KeycloakDefaultHostname,
 		DDMManualEDPComponent:         cnf.DDMManualEDPComponent,
+		UsersRealm:                    cnf.UsersRealm,
+		UsersNamespace:                cnf.UsersNamespace,
 		RegistryDNSManualPath:         cnf.RegistryDNSManualPath,
 	}
 }
@@ -1,79 +1,104 @@
 package config
 
 import (
+	"time"
+
+	"ddm-admin-console/app/registry"
 	"ddm-admin-console/service/codebase"
 	edpcomponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/gitserver"
 	"ddm-admin-console/service/jenkins"
 	"ddm-admin-console/service/k8s"
 	"ddm-admin-console/service/keycloak"
 	"ddm-admin-console/service/openshift"
 	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/roles"
+	"ddm-admin-console/service/session"
 	"ddm-admin-console/service/vault"
 
 	"github.com/patrickmn/go-cache"
 )
 
 type Settings struct {
-	HTTPPort                              string `envconfig:"HTTP_PORT" default:"8080"`
-	LogLevel                              string `envconfig:"LOG_LEVEL" default:"INFO"`
-	LogEncoding                           string `envconfig:"LOG_ENCODING" default:"json"`
-	Namespace                             string `envconfig:"NAMESPACE" default:"default"`
-	SessionSecret                         string `envconfig:"SESSION_SECRET" default:"UdWaTEfULunPTkRC9sFLG26APz9W5gEC8x"`
-	OCClientID                            string `envconfig:"OC_CLIENT_ID"`
-	OCClientSecret                        string `envconfig:"OC_CLIENT_SECRET"`
-	Host                                  string `envconfig:"HOST"`
-	ClusterCodebaseName                   string `envconfig:"CLUSTER_CODEBASE_NAME"`
-	ClusterRepo                           string `envconfig:"CLUSTER_REPO"`
-	BackupSecretName                      string `envconfig:"BACKUP_SECRET_NAME" default:"backup-credential"`
-	GinMode                               string `envconfig:"GIN_MODE"`
-	Timezone                              string `envconfig:"TIMEZONE" default:"Europe/Kiev"`
-	RegistryRepoHost                      string `envconfig:"REGISTRY_REPO_HOST"`
-	RegistryHardwareKeyINITemplatePath    string `envconfig:"REGISTRY_HW_KEY_INI_TPL_PATH" default:"osplm.ini"`
-	RootGerritName                        string `envconfig:"ROOT_GERRIT_NAME" default:"gerrit"`
-	GroupGitRepo                          string `envconfig:"GROUP_GIT_REPO"`
-	UsersNamespace                        string `envconfig:"USERS_NAMESPACE" default:"user-management"`
-	UsersRealm                            string `envconfig:"USERS_REALM" default:"openshift"`
-	EnableBranchProvisioners              bool   `envconfig:"ENABLE_BRANCH_PROVISIONERS"`
-	RegistryCodebaseLabels                string `envconfig:"REGISTRY_CODEBASE_LABELS"`
-	GerritAPIUrlTemplate                  string `envconfig:"GERRIT_API_URL_TPL" default:"http://{HOST}:8080/a/"`
-	JenkinsAPIURL                         string `envconfig:"JENKINS_API_URL" default:"http://jenkins:8080"`
-	JenkinsAdminSecretName                string `envconfig:"JENKINS_ADMIN_SECRET_NAME" default:"jenkins-admin-token"`
-	VaultNamespace                        string `envconfig:"VAULT_NAMESPACE" default:"user-management"`
-	VaultSecretName                       string `envconfig:"VAULT_SECRET_NAME" default:"vault-root-token"`
-	VaultSecretTokenKey                   string `envconfig:"VAULT_SECRET_TOKEN_KEY" default:"VAULT_ROOT_TOKEN"`
-	VaultAPIAddr                          string `envconfig:"VAULT_API_ADDR" default:"http://hashicorp-vault.user-management:8200"`
-	VaultRegistrySecretPathTemplate       string `envconfig:"V_REG_SEC_PATH_TPL" default:"{engine}/registry/{registry}"`
-	VaultRegistrySMTPPwdSecretKey         string `envconfig:"V_REG_SMTP_SEC_KEY" default:"smtp-password"`
-	VaultKVEngineName                     string `envconfig:"VAULT_KV_ENGINE_NAME" default:"registry-kv"`
-	VaultClusterAdminsPathTemplate        string `envconfig:"V_CLS_ADM_PATH_TPL" default:"{engine}/cluster/{admin}"`
-	VaultClusterAdminsPasswordKey         string `envconfig:"V_CLS_ADMIN_SEC_KEY" default:"password"`
-	VaultClusterPathTemplate              string `envconfig:"V_CLS_ADM_PATH_TPL" default:"{engine}/cluster"`
-	VaultClusterKeyManagementPathTemplate string `envconfig:"V_CLS_KEYM_PATH_TPL" default:"{engine}/cluster/key-management"`
-	VaultCitizenSSLPath                   string `encvonfig:"V_SSL_CITIZEN_PATH" default:"custom-dns-names/{registry}/citizen-portal/{host}"`
-	VaultOfficerSSLPath                   string `encvonfig:"V_SSL_CITIZEN_PATH" default:"custom-dns-names/{registry}/officer-portal/{host}"`
-	VaultKeycloakSSLPath                  string `encvonfig:"V_SSL_KEYCLOAK_PATH" default:"custom-dns-names/{registry}/officer-portal/{host}"`
-	TempFolder                            string `envconfig:"TEMP_FOLDER" default:"/tmp"`
-	RegistryDNSManualPath                 string `envconfig:"REGISTRY_DNS_MANUAL_PATH" default:"platform/1.9.4/arch/architecture/platform/administrative/control-plane/keycloak-custom-url.html#_keycloak_dns"`
-	DDMManualEDPComponent                 string `envconfig:"DDM_MANUAL_EDP_COMPONENT" default:"ddm-architecture"`
-	OAuthUseExternalTokenURL              bool   `envconfig:"OAUTH_USE_EXTERNAL_TOKEN_URL"`
-	OAuthInternalTokenHost                string `envconfig:"OAUTH_INTERNAL_TOKEN_HOST" default:"oauth-openshift.openshift-authentication.svc"`
-	GitUsername                           string `envconfig:"GERRIT_GIT_USERNAME" default:"project-creator"`
-	GitKeySecretName                      string `envconfig:"GERRIT_GIT_KEY_SECRET_NAME" default:"gerrit-project-creator"`
-	GitHost                               string `envconfig:"GERRIT_GIT_HOSTNAME" default:"gerrit"`
-	GitPort                               string `envconfig:"GERRIT_GIT_PORT" default:"31000"`
-	KeycloakDefaultHostname               string `envconfig:"KEYCLOAK_DEFAULT_HOSTNAME"`
-	Mock                                  string `envconfig:"MOCK"`
-	RegistryVersionFilter                 string `envconfig:"REGISTRY_VERSION_FILTER"`
-	WiremockAddr                          string `envconfig:"WIREMOCK_ADDR" default:"http://wiremock.{NAME_REGISTRY}:9021/"`
-	BackupBucketAccessKeyID               string `envconfig:"ACCESS_KEY_ID" default:"accessKeyId"`
-	BackupBucketSecretAccessKey           string `envconfig:"SECRET_ACCESS_KEY" default:"secretAccessKey"`
-	RegistryTemplateName                  string `envconfig:"REGISTRY_TEMPLATE_NAME"`
-	CloudProvider                         string `envconfig:"CLOUD_PROVIDER"`
-	Region                                string `envconfig:"REGION" default:"ua"`
-	PlatformVersion                       string `envconfig:"PLATFORM_VERSION"`
-	PreviousPlatfromVersion               string `envconfig:"PREVIOUS_PLATFORM_VERSION"`
+	HTTPPort                              string        `envconfig:"HTTP_PORT" default:"8080"`
+	LogLevel                              string        `envconfig:"LOG_LEVEL" default:"INFO"`
+	LogEncoding                           string        `envconfig:"LOG_ENCODING" default:"json"`
+	Namespace                             string        `envconfig:"NAMESPACE" default:"default"`
+	SessionSecret                         string        `envconfig:"SESSION_SECRET" required:"true" secret:"true"`
+	SessionStore                          string        `envconfig:"SESSION_STORE" default:"secret"`
+	SessionIdleTimeout                    time.Duration `envconfig:"SESSION_IDLE_TIMEOUT" default:"30m"`
+	SessionAbsoluteTimeout                time.Duration `envconfig:"SESSION_ABSOLUTE_TIMEOUT" default:"12h"`
+	OCClientID                            string        `envconfig:"OC_CLIENT_ID"`
+	OCClientSecret                        string        `envconfig:"OC_CLIENT_SECRET" secret:"true"`
+	Host                                  string        `envconfig:"HOST"`
+	ClusterCodebaseName                   string        `envconfig:"CLUSTER_CODEBASE_NAME"`
+	ClusterRepo                           string        `envconfig:"CLUSTER_REPO"`
+	BackupSecretName                      string        `envconfig:"BACKUP_SECRET_NAME" default:"backup-credential"`
+	GinMode                               string        `envconfig:"GIN_MODE"`
+	Timezone                              string        `envconfig:"TIMEZONE" default:"Europe/Kiev"`
+	RegistryRepoHost                      string        `envconfig:"REGISTRY_REPO_HOST"`
+	RegistryHardwareKeyINITemplatePath    string        `envconfig:"REGISTRY_HW_KEY_INI_TPL_PATH" default:"osplm.ini"`
+	RootGerritName                        string        `envconfig:"ROOT_GERRIT_NAME" default:"gerrit"`
+	GitServerName                         string        `envconfig:"GIT_SERVER_NAME" default:"gerrit"`
+	GroupGitRepo                          string        `envconfig:"GROUP_GIT_REPO"`
+	UsersNamespace                        string        `envconfig:"USERS_NAMESPACE" default:"user-management"`
+	UsersRealm                            string        `envconfig:"USERS_REALM" default:"openshift"`
+	OfficersRealm                         string        `envconfig:"OFFICERS_REALM" default:"officer-portal"`
+	EnableBranchProvisioners              bool          `envconfig:"ENABLE_BRANCH_PROVISIONERS"`
+	RegistryCodebaseLabels                string        `envconfig:"REGISTRY_CODEBASE_LABELS"`
+	GerritAPIUrlTemplate                  string        `envconfig:"GERRIT_API_URL_TPL" default:"http://{HOST}:8080/a/"`
+	JenkinsAPIURL                         string        `envconfig:"JENKINS_API_URL" default:"http://jenkins:8080"`
+	JenkinsAdminSecretName                string        `envconfig:"JENKINS_ADMIN_SECRET_NAME" default:"jenkins-admin-token"`
+	VaultNamespace                        string        `envconfig:"VAULT_NAMESPACE" default:"user-management"`
+	VaultSecretName                       string        `envconfig:"VAULT_SECRET_NAME" default:"vault-root-token"`
+	VaultSecretTokenKey                   string        `envconfig:"VAULT_SECRET_TOKEN_KEY" default:"VAULT_ROOT_TOKEN"`
+	VaultAPIAddr                          string        `envconfig:"VAULT_API_ADDR" default:"http://hashicorp-vault.user-management:8200"`
+	VaultRegistrySecretPathTemplate       string        `envconfig:"V_REG_SEC_PATH_TPL" default:"{engine}/registry/{registry}"`
+	VaultRegistrySMTPPwdSecretKey         string        `envconfig:"V_REG_SMTP_SEC_KEY" default:"smtp-password"`
+	VaultKVEngineName                     string        `envconfig:"VAULT_KV_ENGINE_NAME" default:"registry-kv"`
+	VaultClusterAdminsPathTemplate        string        `envconfig:"V_CLS_ADM_PATH_TPL" default:"{engine}/cluster/{admin}"`
+	VaultClusterAdminsPasswordKey         string        `envconfig:"V_CLS_ADMIN_SEC_KEY" default:"password"`
+	VaultClusterPathTemplate              string        `envconfig:"V_CLS_PATH_TPL" default:"{engine}/cluster"`
+	VaultClusterKeyManagementPathTemplate string        `envconfig:"V_CLS_KEYM_PATH_TPL" default:"{engine}/cluster/key-management"`
+	VaultCitizenSSLPath                   string        `envconfig:"V_SSL_CITIZEN_PATH" default:"custom-dns-names/{registry}/citizen-portal/{host}"`
+	VaultOfficerSSLPath                   string        `envconfig:"V_SSL_OFFICER_PATH" default:"custom-dns-names/{registry}/officer-portal/{host}"`
+	VaultKeycloakSSLPath                  string        `envconfig:"V_SSL_KEYCLOAK_PATH" default:"custom-dns-names/{registry}/officer-portal/{host}"`
+	TempFolder                            string        `envconfig:"TEMP_FOLDER" default:"/tmp"`
+	RegistryDNSManualPath                 string        `envconfig:"REGISTRY_DNS_MANUAL_PATH" default:"platform/1.9.4/arch/architecture/platform/administrative/control-plane/keycloak-custom-url.html#_keycloak_dns"`
+	DDMManualEDPComponent                 string        `envconfig:"DDM_MANUAL_EDP_COMPONENT" default:"ddm-architecture"`
+	OAuthUseExternalTokenURL              bool          `envconfig:"OAUTH_USE_EXTERNAL_TOKEN_URL"`
+	OAuthInternalTokenHost                string        `envconfig:"OAUTH_INTERNAL_TOKEN_HOST" default:"oauth-openshift.openshift-authentication.svc"`
+	GitUsername                           string        `envconfig:"GERRIT_GIT_USERNAME" default:"project-creator"`
+	GitKeySecretName                      string        `envconfig:"GERRIT_GIT_KEY_SECRET_NAME" default:"gerrit-project-creator"`
+	GitHost                               string        `envconfig:"GERRIT_GIT_HOSTNAME" default:"gerrit"`
+	GitPort                               string        `envconfig:"GERRIT_GIT_PORT" default:"31000"`
+	KeycloakDefaultHostname               string        `envconfig:"KEYCLOAK_DEFAULT_HOSTNAME"`
+	Mock                                  string        `envconfig:"MOCK"`
+	RegistryVersionFilter                 string        `envconfig:"REGISTRY_VERSION_FILTER"`
+	WiremockAddr                          string        `envconfig:"WIREMOCK_ADDR" default:"http://wiremock.{NAME_REGISTRY}:9021/"`
+	BackupBucketAccessKeyID               string        `envconfig:"ACCESS_KEY_ID" default:"accessKeyId" secret:"true"`
+	BackupBucketSecretAccessKey           string        `envconfig:"SECRET_ACCESS_KEY" default:"secretAccessKey" secret:"true"`
+	RegistryTemplateName                  string        `envconfig:"REGISTRY_TEMPLATE_NAME"`
+	CloudProvider                         string        `envconfig:"CLOUD_PROVIDER"`
+	Region                                string        `envconfig:"REGION" default:"ua"`
+	PlatformVersion                       string        `envconfig:"PLATFORM_VERSION"`
+	PreviousPlatfromVersion               string        `envconfig:"PREVIOUS_PLATFORM_VERSION"`
+	RegistryDeletionRetention             time.Duration `envconfig:"REGISTRY_DELETION_RETENTION" default:"72h"`
+	AdminExpiryCheckInterval              time.Duration `envconfig:"ADMIN_EXPIRY_CHECK_INTERVAL" default:"10m"`
+	MergeRequestConcurrentReconciles      int           `envconfig:"MERGE_REQUEST_CONCURRENT_RECONCILES" default:"4"`
+	GitMirrorFolder                       string        `envconfig:"GIT_MIRROR_FOLDER"`
+	RolesConfigMap                        string        `envconfig:"ROLES_CONFIG_MAP" default:"console-roles"`
+	RuntimeConfigMap                      string        `envconfig:"RUNTIME_CONFIG_MAP" default:"console-runtime-config"`
+	TracingExporter                       string        `envconfig:"TRACING_EXPORTER"`
+	TracingSampleRatio                    float64       `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	ClientTimeout                         time.Duration `envconfig:"CLIENT_TIMEOUT" default:"30s"`
+	ClientRetries                         int           `envconfig:"CLIENT_RETRIES" default:"2"`
+	ClientRetryBackoff                    time.Duration `envconfig:"CLIENT_RETRY_BACKOFF" default:"500ms"`
+	ClientBreakerThreshold                int           `envconfig:"CLIENT_BREAKER_THRESHOLD" default:"5"`
+	ClientBreakerCooldown                 time.Duration `envconfig:"CLIENT_BREAKER_COOLDOWN" default:"30s"`
+	ShutdownTimeout                       time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"60s"`
 }
 
 type Services struct {
@@ -83,9 +108,13 @@ type Services struct {
 	OpenShift    openshift.ServiceInterface
 	Gerrit       gerrit.ServiceInterface
 	GitServer    gitserver.ServiceInterface
+	GitProvider  gitprovider.Provider
 	Jenkins      jenkins.ServiceInterface
 	Keycloak     keycloak.ServiceInterface
 	Vault        vault.ServiceInterface
 	Cache        *cache.Cache //TODO: make interface
 	PermService  permissions.ServiceInterface
+	Roles        roles.ServiceInterface
+	Sessions     session.ServiceInterface
+	Runtime      *registry.Runtime
 }
@@ -8,24 +8,14 @@ import (
 	"ddm-admin-console/app/registry"
 )
 
-func (cnf *Settings) RegistryConfig() (registry.Config, error) {
-	if cnf.PreviousPlatfromVersion == "" {
-		prevVersion, err := calculatePrevVersion(cnf.PlatformVersion)
-		if err != nil {
-			return registry.Config{}, fmt.Errorf("failed to calculate previous version, %w", err)
-		}
-
-		cnf.PreviousPlatfromVersion = prevVersion
-	}
-
+func (cnf *Settings) RegistryConfig() registry.Config {
 	return registry.Config{
 		UsersNamespace:                  cnf.UsersNamespace,
 		UsersRealm:                      cnf.UsersRealm,
-		RegistryCodebaseLabels:          cnf.RegistryCodebaseLabels,
+		OfficersRealm:                   cnf.OfficersRealm,
 		EnableBranchProvisioners:        cnf.EnableBranchProvisioners,
 		ClusterCodebaseName:             cnf.ClusterCodebaseName,
 		ClusterRepo:                     cnf.ClusterRepo,
-		Timezone:                        cnf.Timezone,
 		GerritRegistryHost:              cnf.RegistryRepoHost,
 		HardwareINITemplatePath:         cnf.RegistryHardwareKeyINITemplatePath,
 		VaultRegistrySMTPPwdSecretKey:   cnf.VaultRegistrySMTPPwdSecretKey,
@@ -36,17 +26,16 @@ func (cnf *Settings) RegistryConfig() (registry.Config, error) {
 		TempFolder:                      cnf.TempFolder,
 		RegistryDNSManualPath:           cnf.RegistryDNSManualPath,
 		DDMManualEDPComponent:           cnf.DDMManualEDPComponent,
-		RegistryVersionFilter:           cnf.RegistryVersionFilter,
 		KeycloakDefaultHostname:         cnf.KeycloakDefaultHostname,
 		WiremockAddr:                    cnf.WiremockAddr,
 		BackupBucketAccessKeyID:         cnf.BackupBucketAccessKeyID,
 		BackupBucketSecretAccessKey:     cnf.BackupBucketSecretAccessKey,
 		RegistryTemplateName:            cnf.RegistryTemplateName,
+		GitServerName:                   cnf.GitServerName,
 		CloudProvider:                   cnf.CloudProvider,
 		Region:                          cnf.Region,
-		CurrentVersion:                  cnf.PlatformVersion,
-		PreviousVersion:                 cnf.PreviousPlatfromVersion,
-	}, nil
+		DeletionRetention:               cnf.RegistryDeletionRetention,
+	}
 }
 
 func calculatePrevVersion(currVersion string) (string, error) {
@@ -85,10 +74,12 @@ func (s *Services) RegistryServices() registry.Services {
 		Keycloak:     s.Keycloak,
 		Jenkins:      s.Jenkins,
 		Gerrit:       s.Gerrit,
+		GitProvider:  s.GitProvider,
 		EDPComponent: s.EDPComponent,
 		Vault:        s.Vault,
 		Cache:        s.Cache,
 		Perms:        s.PermService,
 		OpenShift:    s.OpenShift,
+		Runtime:      s.Runtime,
 	}
 }
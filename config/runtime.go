@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ddm-admin-console/app/registry"
+)
+
+const (
+	keyRegistryVersionFilter  = "REGISTRY_VERSION_FILTER"
+	keyTimezone               = "TIMEZONE"
+	keyRegistryCodebaseLabels = "REGISTRY_CODEBASE_LABELS"
+	keyPlatformVersion        = "PLATFORM_VERSION"
+	keyPreviousVersion        = "PREVIOUS_PLATFORM_VERSION"
+)
+
+// RuntimeChange is a setting changed by reload of runtime config
+type RuntimeChange struct {
+	Key string
+	Old string
+	New string
+}
+
+func (c RuntimeChange) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Key, c.Old, c.New)
+}
+
+// RuntimeConfig prepares reloadable part of configuration, overrides are keyed by setting names
+// and replace values of settings, previous version is calculated when only platform version is overridden
+func (cnf *Settings) RuntimeConfig(overrides map[string]string) (*registry.RuntimeConfig, error) {
+	values := map[string]string{
+		keyRegistryVersionFilter:  cnf.RegistryVersionFilter,
+		keyTimezone:               cnf.Timezone,
+		keyRegistryCodebaseLabels: cnf.RegistryCodebaseLabels,
+		keyPlatformVersion:        cnf.PlatformVersion,
+		keyPreviousVersion:        cnf.PreviousPlatfromVersion,
+	}
+
+	for k, v := range overrides {
+		if _, ok := values[k]; !ok {
+			return nil, fmt.Errorf("setting %s can not be reloaded", k)
+		}
+
+		values[k] = strings.TrimSpace(v)
+	}
+
+	if _, ok := overrides[keyPreviousVersion]; !ok {
+		if _, ok := overrides[keyPlatformVersion]; ok {
+			values[keyPreviousVersion] = ""
+		}
+	}
+
+	vf, err := registry.MakeVersionFilter(values[keyRegistryVersionFilter])
+	if err != nil {
+		return nil, fmt.Errorf("unable to init version filter, %w", err)
+	}
+
+	if values[keyPreviousVersion] == "" {
+		prevVersion, err := calculatePrevVersion(values[keyPlatformVersion])
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate previous version, %w", err)
+		}
+
+		values[keyPreviousVersion] = prevVersion
+	}
+
+	return &registry.RuntimeConfig{
+		VersionFilter:   vf,
+		Timezone:        values[keyTimezone],
+		CodebaseLabels:  registry.ParseCodebaseLabels(values[keyRegistryCodebaseLabels]),
+		CurrentVersion:  values[keyPlatformVersion],
+		PreviousVersion: values[keyPreviousVersion],
+	}, nil
+}
+
+// RuntimeChanges returns settings which differ in runtime configs sorted by key
+func RuntimeChanges(old, new *registry.RuntimeConfig) []RuntimeChange {
+	oldValues, newValues := runtimeValues(old), runtimeValues(new)
+
+	var changes []RuntimeChange
+
+	for k, v := range newValues {
+		if oldValues[k] != v {
+			changes = append(changes, RuntimeChange{Key: k, Old: oldValues[k], New: v})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	return changes
+}
+
+func runtimeValues(rc *registry.RuntimeConfig) map[string]string {
+	labels := make([]string, 0, len(rc.CodebaseLabels))
+	for k, v := range rc.CodebaseLabels {
+		labels = append(labels, k+"="+v)
+	}
+
+	sort.Strings(labels)
+
+	return map[string]string{
+		keyRegistryVersionFilter:  rc.VersionFilter.String(),
+		keyTimezone:               rc.Timezone,
+		keyRegistryCodebaseLabels: strings.Join(labels, ","),
+		keyPlatformVersion:        rc.CurrentVersion,
+		keyPreviousVersion:        rc.PreviousVersion,
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings_RuntimeConfig(t *testing.T) {
+	t.Parallel()
+
+	cnf := validSettings()
+	cnf.PreviousPlatfromVersion = "1.9.3"
+	cnf.Timezone = "Europe/Kiev"
+	cnf.RegistryCodebaseLabels = "app=registry,broken"
+
+	startup, err := cnf.RuntimeConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, "1.9.3", startup.PreviousVersion)
+	require.Equal(t, map[string]string{"app": "registry"}, startup.CodebaseLabels)
+
+	reloaded, err := cnf.RuntimeConfig(map[string]string{
+		"PLATFORM_VERSION":        "1.9.7",
+		"REGISTRY_VERSION_FILTER": " >1.9 ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.9.6", reloaded.PreviousVersion, "previous version follows reloaded platform version")
+	require.Equal(t, "Europe/Kiev", reloaded.Timezone)
+
+	require.Equal(t, []RuntimeChange{
+		{Key: "PLATFORM_VERSION", Old: "1.9.5", New: "1.9.7"},
+		{Key: "PREVIOUS_PLATFORM_VERSION", Old: "1.9.3", New: "1.9.6"},
+		{Key: "REGISTRY_VERSION_FILTER", Old: "", New: ">1.9"},
+	}, RuntimeChanges(startup, reloaded))
+	require.Empty(t, RuntimeChanges(reloaded, reloaded))
+
+	_, err = cnf.RuntimeConfig(map[string]string{"REGISTRY_VERSION_FILTER": "~1.9"})
+	require.Error(t, err)
+
+	_, err = cnf.RuntimeConfig(map[string]string{"HTTP_PORT": "8081"})
+	require.EqualError(t, err, "setting HTTP_PORT can not be reloaded")
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ddm-admin-console/router"
+	"ddm-admin-console/service/session"
+)
+
+const (
+	SessionStoreSecret = "secret"
+	SessionStoreMemory = "memory"
+
+	minSessionSecretLength = 32
+)
+
+// insecureSessionSecrets were shipped as defaults, session cookies signed with them can be forged by anyone
+var insecureSessionSecrets = []string{"UdWaTEfULunPTkRC9sFLG26APz9W5gEC8x"}
+
+// ValidateSession refuses settings which make sessions unsafe or unusable
+func (cnf *Settings) ValidateSession() error {
+	for _, s := range insecureSessionSecrets {
+		if cnf.SessionSecret == s {
+			return errors.New("SESSION_SECRET has publicly known default value, set unique secret")
+		}
+	}
+
+	if len(cnf.SessionSecret) < minSessionSecretLength {
+		return fmt.Errorf("SESSION_SECRET must be at least %d characters long", minSessionSecretLength)
+	}
+
+	if cnf.SessionStore != SessionStoreSecret && cnf.SessionStore != SessionStoreMemory {
+		return fmt.Errorf("unknown SESSION_STORE: %s", cnf.SessionStore)
+	}
+
+	return nil
+}
+
+func (cnf *Settings) SessionConfig() session.Config {
+	return session.Config{
+		IdleTimeout:     cnf.SessionIdleTimeout,
+		AbsoluteTimeout: cnf.SessionAbsoluteTimeout,
+		UserKey:         router.UserEmailSessionKey,
+		Secure:          strings.HasPrefix(cnf.Host, "https://"),
+	}
+}
@@ -0,0 +1,314 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/tracing"
+)
+
+const redacted = "******"
+
+// externalKeys are read from env outside of Settings
+var externalKeys = map[string]struct{}{
+	"LANGUAGE":      {},
+	"PLATFORM_NAME": {},
+}
+
+// envKeyPrefixes are prefixes of console settings, environment variables with them and unknown names
+// are most likely misspelled settings
+var envKeyPrefixes = []string{"V_", "VAULT_", "SESSION_", "REGISTRY_", "GERRIT_", "JENKINS_", "OAUTH_", "OC_",
+	"TRACING_", "USERS_", "CLUSTER_"}
+
+// serviceLinkKey matches variables kubernetes adds to pods for services of namespace, e.g. GERRIT_SERVICE_HOST
+var serviceLinkKey = regexp.MustCompile(`_(SERVICE_HOST|SERVICE_PORT(_[A-Z0-9_]+)?|PORT(_\d+_(TCP|UDP|SCTP)(_PROTO|_PORT|_ADDR)?)?)$`)
+
+// Problem of configuration, warnings are reported but do not prevent console from starting
+type Problem struct {
+	Key     string
+	Message string
+	Warning bool
+}
+
+func (p Problem) String() string {
+	level := "error"
+	if p.Warning {
+		level = "warning"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", level, p.Key, p.Message)
+}
+
+type Problems []Problem
+
+// Err joins problems which are not warnings
+func (ps Problems) Err() error {
+	var msgs []string
+
+	for _, p := range ps {
+		if !p.Warning {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", p.Key, p.Message))
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// Setting is effective value of configuration key
+type Setting struct {
+	Key   string
+	Value string
+}
+
+type settingField struct {
+	key    string
+	secret bool
+	index  int
+}
+
+// settingFields returns keys of Settings fields in declaration order, fields without envconfig tag have empty key
+func settingFields() []settingField {
+	t := reflect.TypeOf(Settings{})
+	fields := make([]settingField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fields = append(fields, settingField{
+			key:    f.Tag.Get("envconfig"),
+			secret: f.Tag.Get("secret") == "true",
+			index:  i,
+		})
+	}
+
+	return fields
+}
+
+// Check validates loaded settings, fileKeys are keys of config file and envKeys are keys of process
+// environment, both are checked for unknown names
+func (cnf *Settings) Check(fileKeys, envKeys []string) Problems {
+	var problems Problems
+
+	problems = append(problems, checkKeys(fileKeys, envKeys)...)
+	problems = append(problems, cnf.checkRequired()...)
+
+	if err := cnf.ValidateSession(); err != nil {
+		problems = append(problems, Problem{Key: "SESSION_SECRET", Message: err.Error()})
+	}
+
+	if _, err := registry.MakeVersionFilter(cnf.RegistryVersionFilter); err != nil {
+		problems = append(problems, Problem{Key: "REGISTRY_VERSION_FILTER", Message: err.Error()})
+	}
+
+	// registry config is prepared in mock mode too
+	if cnf.PreviousPlatfromVersion == "" {
+		if _, err := calculatePrevVersion(cnf.PlatformVersion); err != nil {
+			problems = append(problems, Problem{Key: "PLATFORM_VERSION",
+				Message: fmt.Sprintf("unable to calculate previous version, set PREVIOUS_PLATFORM_VERSION, %s", err)})
+		}
+	}
+
+	switch cnf.TracingExporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		problems = append(problems, Problem{Key: "TRACING_EXPORTER", Message: "unknown exporter " + cnf.TracingExporter})
+	}
+
+	problems = append(problems, cnf.checkTemplates()...)
+
+	return problems
+}
+
+// EnvKeys returns sorted keys of environment in os.Environ format
+func EnvKeys(environ []string) []string {
+	keys := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if k, _, _ := strings.Cut(kv, "="); k != "" {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// checkKeys reports settings bound to the same key, fields without key, unknown keys of config file
+// and unknown keys of environment which have prefix of console settings
+func checkKeys(fileKeys, envKeys []string) Problems {
+	var (
+		problems Problems
+		known    = make(map[string]struct{})
+		t        = reflect.TypeOf(Settings{})
+	)
+
+	for _, f := range settingFields() {
+		name := t.Field(f.index).Name
+
+		if f.key == "" {
+			problems = append(problems, Problem{Key: name, Message: "setting has no envconfig tag"})
+			continue
+		}
+
+		if _, ok := known[f.key]; ok {
+			problems = append(problems, Problem{Key: f.key, Message: "key is bound to several settings, " + name + " is one of them"})
+		}
+
+		known[f.key] = struct{}{}
+	}
+
+	for _, k := range fileKeys {
+		if _, ok := known[k]; ok {
+			continue
+		}
+
+		if _, ok := externalKeys[k]; ok {
+			continue
+		}
+
+		problems = append(problems, Problem{Key: k, Message: "unknown key", Warning: true})
+	}
+
+	fromFile := make(map[string]struct{}, len(fileKeys))
+	for _, k := range fileKeys {
+		fromFile[k] = struct{}{}
+	}
+
+	for _, k := range envKeys {
+		if _, ok := known[k]; ok {
+			continue
+		}
+
+		// config file is loaded into environment, its keys are already checked
+		if _, ok := fromFile[k]; ok {
+			continue
+		}
+
+		if !hasEnvKeyPrefix(k) || serviceLinkKey.MatchString(k) {
+			continue
+		}
+
+		problems = append(problems, Problem{Key: k, Message: "unknown environment variable", Warning: true})
+	}
+
+	return problems
+}
+
+func hasEnvKeyPrefix(key string) bool {
+	for _, p := range envKeyPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkRequired reports empty settings which are needed outside of mock mode
+func (cnf *Settings) checkRequired() Problems {
+	if cnf.Mock != "" {
+		return nil
+	}
+
+	var problems Problems
+
+	for key, val := range map[string]string{
+		"OC_CLIENT_ID":          cnf.OCClientID,
+		"OC_CLIENT_SECRET":      cnf.OCClientSecret,
+		"HOST":                  cnf.Host,
+		"CLUSTER_CODEBASE_NAME": cnf.ClusterCodebaseName,
+		"CLUSTER_REPO":          cnf.ClusterRepo,
+		"REGISTRY_REPO_HOST":    cnf.RegistryRepoHost,
+		"GIT_SERVER_NAME":       cnf.GitServerName,
+	} {
+		if val == "" {
+			problems = append(problems, Problem{Key: key, Message: "required setting is empty"})
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+
+	return problems
+}
+
+// checkTemplates reports urls and path templates without placeholders they are rendered with
+func (cnf *Settings) checkTemplates() Problems {
+	var problems Problems
+
+	urls := []struct {
+		key, value, placeholder string
+	}{
+		{"HOST", cnf.Host, ""},
+		{"GERRIT_API_URL_TPL", cnf.GerritAPIUrlTemplate, "{HOST}"},
+		{"JENKINS_API_URL", cnf.JenkinsAPIURL, ""},
+		{"VAULT_API_ADDR", cnf.VaultAPIAddr, ""},
+		{"WIREMOCK_ADDR", cnf.WiremockAddr, "{NAME_REGISTRY}"},
+	}
+
+	for _, u := range urls {
+		if u.value == "" {
+			continue
+		}
+
+		if u.placeholder != "" && !strings.Contains(u.value, u.placeholder) {
+			problems = append(problems, Problem{Key: u.key, Message: "template has no " + u.placeholder + " placeholder"})
+		}
+
+		value := u.value
+		if u.placeholder != "" {
+			value = strings.ReplaceAll(value, u.placeholder, "placeholder")
+		}
+
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			problems = append(problems, Problem{Key: u.key, Message: "value is not an absolute url"})
+		}
+	}
+
+	paths := []struct {
+		key, value   string
+		placeholders []string
+	}{
+		{"V_REG_SEC_PATH_TPL", cnf.VaultRegistrySecretPathTemplate, []string{"{registry}"}},
+		{"V_CLS_PATH_TPL", cnf.VaultClusterPathTemplate, []string{"{engine}"}},
+		{"V_SSL_CITIZEN_PATH", cnf.VaultCitizenSSLPath, []string{"{registry}", "{host}"}},
+		{"V_SSL_OFFICER_PATH", cnf.VaultOfficerSSLPath, []string{"{registry}", "{host}"}},
+		{"V_SSL_KEYCLOAK_PATH", cnf.VaultKeycloakSSLPath, []string{"{registry}", "{host}"}},
+	}
+
+	for _, p := range paths {
+		for _, ph := range p.placeholders {
+			if !strings.Contains(p.value, ph) {
+				problems = append(problems, Problem{Key: p.key, Message: "template has no " + ph + " placeholder"})
+			}
+		}
+	}
+
+	return problems
+}
+
+// Effective returns settings in declaration order, values of secrets are redacted
+func (cnf *Settings) Effective() []Setting {
+	v := reflect.ValueOf(*cnf)
+	settings := make([]Setting, 0, v.NumField())
+
+	for _, f := range settingFields() {
+		val := fmt.Sprint(v.Field(f.index).Interface())
+		if f.secret && val != "" {
+			val = redacted
+		}
+
+		settings = append(settings, Setting{Key: f.key, Value: val})
+	}
+
+	return settings
+}
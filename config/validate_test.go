@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validSettings() *Settings {
+	return &Settings{
+		SessionSecret:                   "0123456789abcdef0123456789abcdef",
+		SessionStore:                    SessionStoreSecret,
+		OCClientID:                      "console",
+		OCClientSecret:                  "client-secret",
+		Host:                            "https://console.example.com",
+		ClusterCodebaseName:             "cluster-mgmt",
+		ClusterRepo:                     "cluster-mgmt",
+		RegistryRepoHost:                "https://gerrit.example.com",
+		GitServerName:                   "gerrit",
+		GerritAPIUrlTemplate:            "http://{HOST}:8080/a/",
+		JenkinsAPIURL:                   "http://jenkins:8080",
+		VaultAPIAddr:                    "http://vault:8200",
+		WiremockAddr:                    "http://wiremock.{NAME_REGISTRY}:9021/",
+		VaultRegistrySecretPathTemplate: "{engine}/registry/{registry}",
+		VaultClusterPathTemplate:        "{engine}/cluster",
+		VaultCitizenSSLPath:             "custom-dns-names/{registry}/citizen-portal/{host}",
+		VaultOfficerSSLPath:             "custom-dns-names/{registry}/officer-portal/{host}",
+		VaultKeycloakSSLPath:            "custom-dns-names/{registry}/keycloak/{host}",
+		PlatformVersion:                 "1.9.5",
+	}
+}
+
+func TestSettings_Check(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, validSettings().Check([]string{"HTTP_PORT", "LANGUAGE"}, nil), "every setting has unique key")
+
+	cnf := validSettings()
+	cnf.RegistryVersionFilter = "~1.9"
+	cnf.GerritAPIUrlTemplate = "http://gerrit:8080/a/"
+	cnf.OCClientSecret = ""
+
+	problems := cnf.Check([]string{"V_CLS_ADMIN_PATH_TPL"}, EnvKeys([]string{
+		"V_CLS_ADMIN_PATH_TPL=", "VAULT_ADRR=http://vault:8200", "HTTP_PORT=8080", "HTTP_PROXY=http://proxy",
+		"GERRIT_SERVICE_HOST=10.0.0.1", "VAULT_PORT_8200_TCP_ADDR=10.0.0.2", "HOME=/root",
+	}))
+	require.Error(t, problems.Err())
+
+	keys := make(map[string]bool)
+	for _, p := range problems {
+		keys[p.Key] = p.Warning
+	}
+
+	require.Equal(t, map[string]bool{
+		"V_CLS_ADMIN_PATH_TPL":    true,
+		"VAULT_ADRR":              true,
+		"OC_CLIENT_SECRET":        false,
+		"REGISTRY_VERSION_FILTER": false,
+		"GERRIT_API_URL_TPL":      false,
+	}, keys)
+
+	cnf = validSettings()
+	cnf.Mock = "true"
+	cnf.OCClientID = ""
+	require.NoError(t, cnf.Check(nil, nil).Err(), "oauth is not required in mock mode")
+}
+
+func TestSettings_Effective(t *testing.T) {
+	t.Parallel()
+
+	values := make(map[string]string)
+	for _, s := range validSettings().Effective() {
+		values[s.Key] = s.Value
+	}
+
+	require.Equal(t, redacted, values["SESSION_SECRET"])
+	require.Equal(t, redacted, values["OC_CLIENT_SECRET"])
+	require.Equal(t, "console", values["OC_CLIENT_ID"])
+	require.Equal(t, "", values["ACCESS_KEY_ID"], "empty secret is shown as empty")
+}
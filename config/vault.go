@@ -9,5 +9,6 @@ func (cnf *Settings) VaultConfig() vault.Config {
 		SecretName:      cnf.VaultSecretName,
 		APIAddr:         cnf.VaultAPIAddr,
 		KVEngineName:    cnf.VaultKVEngineName,
+		ClientPolicy:    cnf.ClientPolicy(),
 	}
 }
@@ -0,0 +1,63 @@
+package admin_expiry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/config"
+	"ddm-admin-console/controller"
+)
+
+// Controller periodically disables platform administrators which expiry date has passed.
+// Realm users live outside of console namespace, so they are polled instead of being watched.
+type Controller struct {
+	logger   controller.Logger
+	admins   *registry.Admins
+	interval time.Duration
+}
+
+func Make(mgr ctrl.Manager, logger controller.Logger, cnf *config.Settings, admins *registry.Admins) error {
+	c := Controller{
+		logger:   logger,
+		admins:   admins,
+		interval: cnf.AdminExpiryCheckInterval,
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(c.Start)); err != nil {
+		return fmt.Errorf("unable to add admin expiry runnable, %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Controller) check(ctx context.Context) {
+	disabled, err := c.admins.DisableExpired(ctx, time.Now())
+	if err != nil {
+		c.logger.Errorw(err.Error(), "disabled", disabled)
+		return
+	}
+
+	if len(disabled) > 0 {
+		c.logger.Infow("expired admins disabled", "admins", disabled)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"ddm-admin-console/app/registry"
 	"ddm-admin-console/config"
 	"ddm-admin-console/controller"
+	"ddm-admin-console/lifecycle"
 	"ddm-admin-console/service"
 	codebaseService "ddm-admin-console/service/codebase"
 	gerritService "ddm-admin-console/service/gerrit"
 	"ddm-admin-console/service/git"
+	"ddm-admin-console/service/valuesdoc"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,7 +20,6 @@ import (
 	"time"
 
 	"github.com/patrickmn/go-cache"
-	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,8 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -38,14 +41,14 @@ const (
 const DefaultRetryTimeout = time.Second * 15
 
 type Controller struct {
-	logger        controller.Logger
-	mgr           ctrl.Manager
-	k8sClient     client.Client
-	cnf           *config.Settings
-	appCache      *cache.Cache
-	versionFilter *registry.VersionFilter
-	gerrit        gerritService.ServiceInterface
-	codebase      codebaseService.ServiceInterface
+	logger    controller.Logger
+	mgr       ctrl.Manager
+	k8sClient client.Client
+	cnf       *config.Settings
+	appCache  *cache.Cache
+	runtime   *registry.Runtime
+	gerrit    gerritService.ServiceInterface
+	codebase  codebaseService.ServiceInterface
 }
 
 type AdminSyncer interface {
@@ -53,7 +56,7 @@ type AdminSyncer interface {
 }
 
 func Make(mgr ctrl.Manager, logger controller.Logger, cnf *config.Settings, _c *cache.Cache,
-	gerrit gerritService.ServiceInterface, cbService codebaseService.ServiceInterface) error {
+	gerrit gerritService.ServiceInterface, cbService codebaseService.ServiceInterface, runtime *registry.Runtime) error {
 	c := Controller{
 		mgr:       mgr,
 		logger:    logger,
@@ -62,17 +65,16 @@ func Make(mgr ctrl.Manager, logger controller.Logger, cnf *config.Settings, _c *
 		appCache:  _c,
 		gerrit:    gerrit,
 		codebase:  cbService,
+		runtime:   runtime,
 	}
 
-	vf, err := registry.MakeVersionFilter(cnf.RegistryVersionFilter)
-	if err != nil {
-		return fmt.Errorf("unable to init version filter, %w", err)
-	}
-	c.versionFilter = vf
-
 	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&codebaseService.Codebase{}, builder.WithPredicates(predicate.Funcs{
 			UpdateFunc: isSpecUpdated})).
+		// registry version changes when update merge request is merged
+		Watches(&source.Kind{Type: &gerritService.GerritMergeRequest{}},
+			handler.EnqueueRequestsFromMapFunc(mergeRequestCodebase),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: isMergeRequestStatusUpdated})).
 		Complete(&c); err != nil {
 		return fmt.Errorf("unable to create controller, %w", err)
 	}
@@ -89,23 +91,19 @@ func ProcessRegistryVersion(
 	bool,
 	error,
 ) {
-	cbs := []codebaseService.Codebase{*cb}
-
-	if err := registry.LoadRegistryVersions(ctx, gr, cbs); err != nil {
-		return false, fmt.Errorf("unable to load registry version, %w", err)
+	registryVersionCodebase := *cb
+	if v, ok := cb.StoredVersion(); ok {
+		registryVersionCodebase.Version = v
+		return versionFilter.CheckCodebase(&registryVersionCodebase), nil
 	}
 
-	registryVersionCodebase := cbs[0]
-
-	if registryVersionCodebase.Version.Original() == "0" {
-		clusterProject, err := gr.GetProject(ctx, cb.Name)
-		if err != nil {
-			return false, fmt.Errorf("unable to get cluster gerrit project, %w", err)
-		}
-
-		registryVersionCodebase.Version = registry.LowestVersion(registry.UpdateBranches(clusterProject.Status.Branches))
+	rv, err := registry.ComputeRegistryVersion(ctx, gr, cb)
+	if err != nil {
+		return false, fmt.Errorf("unable to compute registry version, %w", err)
 	}
 
+	registryVersionCodebase.Version = rv.Version
+
 	return versionFilter.CheckCodebase(&registryVersionCodebase), nil
 }
 
@@ -113,6 +111,24 @@ func GerritSSHURL(cnf *config.Settings) string {
 	return fmt.Sprintf("ssh://%s@%s:%s", cnf.GitUsername, cnf.GitHost, cnf.GitPort)
 }
 
+func mergeRequestCodebase(obj client.Object) []reconcile.Request {
+	mr, ok := obj.(*gerritService.GerritMergeRequest)
+	if !ok || mr.Spec.ProjectName == "" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: mr.Namespace, Name: mr.Spec.ProjectName}},
+	}
+}
+
+func isMergeRequestStatusUpdated(e event.UpdateEvent) bool {
+	oo := e.ObjectOld.(*gerritService.GerritMergeRequest)
+	no := e.ObjectNew.(*gerritService.GerritMergeRequest)
+
+	return oo.Status.Value != no.Status.Value
+}
+
 func isSpecUpdated(e event.UpdateEvent) bool {
 	oo := e.ObjectOld.(*codebaseService.Codebase)
 	no := e.ObjectNew.(*codebaseService.Codebase)
@@ -124,6 +140,8 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 func (c *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	c.logger.Infow("reconciling codebase", "Request.Namespace", request.Namespace,
 		"Request.Name", request.Name)
+	// reconcile pushes to git, so it is finished at shutdown instead of being interrupted
+	ctx = lifecycle.WithoutCancel(ctx)
 
 	if err := c.reconcile(ctx, request); err != nil {
 		c.logger.Errorw(err.Error(), "Request.Namespace", request.Namespace, "Request.Name", request.Name)
@@ -156,12 +174,25 @@ func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) e
 		return nil
 	}
 
-	processRequest, err := ProcessRegistryVersion(ctx, c.versionFilter, &instance, c.gerrit)
+	rv, err := registry.ComputeRegistryVersion(ctx, c.gerrit, &instance)
 	if err != nil {
-		return fmt.Errorf("unable to p, err: %w", err)
+		return fmt.Errorf("unable to compute registry version, %w", err)
 	}
 
-	if !processRequest {
+	// registry is owned by console which filter matches the fresh version, so after update merge request is merged
+	// it is handed over to console of the new version even if stored version is not updated yet
+	versionFilter := c.runtime.Load().VersionFilter
+	owns := versionFilter.Check(rv.Version)
+	stored, hasStored := instance.StoredVersion()
+
+	// only consoles of the stored and the fresh version store it, so consoles of other versions do not fight over it
+	if owns || (hasStored && versionFilter.Check(stored)) {
+		if err := c.storeRegistryVersion(ctx, &instance, rv); err != nil {
+			return fmt.Errorf("unable to store registry version, %w", err)
+		}
+	}
+
+	if !owns {
 		c.logger.Infow("reconciling codebase skipped, wrong registry version",
 			"Request.Namespace", request.Namespace, "Request.Name", request.Name)
 		return nil
@@ -178,6 +209,20 @@ func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) e
 	return nil
 }
 
+// storeRegistryVersion precomputes effective registry version, so it is not calculated on each read
+func (c *Controller) storeRegistryVersion(ctx context.Context, instance *codebaseService.Codebase,
+	rv *registry.RegistryVersion) error {
+	if !registry.SetRegistryVersionAnnotations(instance, rv) {
+		return nil
+	}
+
+	if err := c.k8sClient.Update(ctx, instance); err != nil {
+		return fmt.Errorf("unable to update codebase, %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) checkBranchesStatus(ctx context.Context, instance *codebaseService.Codebase) error {
 	branches, err := c.codebase.GetBranchesByCodebase(ctx, instance.Name)
 	if err != nil {
@@ -419,22 +464,21 @@ func updateRegistryValues(instance *codebaseService.Codebase, gitService *git.Se
 		return false, fmt.Errorf("unable to get values from repo, %w", err)
 	}
 
-	var currentValues map[string]interface{}
-	if err := yaml.Unmarshal([]byte(currentValuesValuesStr), &currentValues); err != nil {
+	doc, err := valuesdoc.Parse([]byte(currentValuesValuesStr))
+	if err != nil {
 		return false, fmt.Errorf("unable to decode values, %w", err)
 	}
-	if currentValues == nil {
-		currentValues = make(map[string]interface{})
-	}
 
 	var instanceValues map[string]interface{}
 	if err := json.Unmarshal([]byte(instance.Annotations[registry.AnnotationValues]), &instanceValues); err != nil {
 		return false, fmt.Errorf("unable to decode codebase values, %w", err)
 	}
 
-	mergedValues := MergeMaps(currentValues, instanceValues)
+	if err := doc.Merge(instanceValues); err != nil {
+		return false, fmt.Errorf("unable to merge values, %w", err)
+	}
 
-	bts, err := yaml.Marshal(mergedValues)
+	bts, err := doc.Bytes()
 	if err != nil {
 		return false, fmt.Errorf("unable to encode values yaml, %w", err)
 	}
@@ -457,22 +501,7 @@ func updateRegistryValues(instance *codebaseService.Codebase, gitService *git.Se
 }
 
 func MergeMaps(a, b map[string]interface{}) map[string]interface{} {
-	out := make(map[string]interface{}, len(a))
-	for k, v := range a {
-		out[k] = v
-	}
-	for k, v := range b {
-		if v, ok := v.(map[string]interface{}); ok {
-			if bv, ok := out[k]; ok {
-				if bv, ok := bv.(map[string]interface{}); ok {
-					out[k] = MergeMaps(bv, v)
-					continue
-				}
-			}
-		}
-		out[k] = v
-	}
-	return out
+	return valuesdoc.MergeMaps(a, b)
 }
 
 func (c *Controller) getGerritProject(ctx context.Context, name string) (*gerritService.GerritProject, error) {
@@ -0,0 +1,134 @@
+package codebase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	pkgScheme "sigs.k8s.io/controller-runtime/pkg/scheme"
+
+	"ddm-admin-console/app/registry"
+	mockCodebase "ddm-admin-console/mocks/codebase"
+	mockGerrit "ddm-admin-console/mocks/gerrit"
+	codebaseService "ddm-admin-console/service/codebase"
+	gerritService "ddm-admin-console/service/gerrit"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	sch := runtime.NewScheme()
+	builder := pkgScheme.Builder{GroupVersion: schema.GroupVersion{Group: "v2.edp.epam.com", Version: "v1alpha1"}}
+	builder.Register(&codebaseService.Codebase{}, &codebaseService.CodebaseList{})
+	require.NoError(t, builder.AddToScheme(sch))
+
+	return sch
+}
+
+func TestController_ReconcileSkipsRegistryOfOtherVersion(t *testing.T) {
+	t.Parallel()
+
+	sch := testScheme(t)
+
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&codebaseService.Codebase{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ns"},
+		Spec: codebaseService.CodebaseSpec{
+			Type:                        codebaseService.RegistryCodebaseType,
+			DefaultBranch:               "master",
+			BranchToCopyInDefaultBranch: "1.9.3",
+		},
+	}).Build()
+
+	gerrit := mockGerrit.ServiceInterface{}
+	gerrit.On("FindMergeRequests", mock.Anything, mock.Anything).Return([]gerritService.GerritMergeRequest{}, nil)
+
+	versionFilter, err := registry.MakeVersionFilter(">=1.9.5")
+	require.NoError(t, err)
+
+	c := Controller{
+		logger:    zap.NewNop().Sugar(),
+		k8sClient: cl,
+		gerrit:    &gerrit,
+		runtime:   registry.MakeRuntime(&registry.RuntimeConfig{VersionFilter: versionFilter}),
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "registry"}}
+	require.NoError(t, c.reconcile(context.Background(), request))
+
+	var cb codebaseService.Codebase
+	require.NoError(t, cl.Get(context.Background(), request.NamespacedName, &cb))
+	_, stored := cb.StoredVersion()
+	require.False(t, stored, "registry version is stored only by console that owns the registry")
+}
+
+func TestController_ReconcileHandsRegistryOverToNewVersionFilter(t *testing.T) {
+	t.Parallel()
+
+	registryCodebase := func() *codebaseService.Codebase {
+		return &codebaseService.Codebase{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ns", Annotations: map[string]string{
+				codebaseService.VersionAnnotation: "1.9.3",
+			}},
+			Spec: codebaseService.CodebaseSpec{
+				Type:                        codebaseService.RegistryCodebaseType,
+				DefaultBranch:               "master",
+				BranchToCopyInDefaultBranch: "1.9.3",
+			},
+		}
+	}
+
+	gerrit := mockGerrit.ServiceInterface{}
+	gerrit.On("FindMergeRequests", mock.Anything, mock.Anything).Return([]gerritService.GerritMergeRequest{{
+		ObjectMeta: metav1.ObjectMeta{Name: "update", Namespace: "ns", Labels: map[string]string{
+			registry.MRLabelTarget: registry.MRTargetRegistryVersionUpdate,
+		}},
+		Spec:   gerritService.GerritMergeRequestSpec{ProjectName: "registry", SourceBranch: "1.9.5"},
+		Status: gerritService.GerritMergeRequestStatus{Value: gerritService.StatusMerged},
+	}}, nil)
+
+	reconcileWith := func(filter string, cbService codebaseService.ServiceInterface) *codebaseService.Codebase {
+		cl := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(registryCodebase()).Build()
+
+		versionFilter, err := registry.MakeVersionFilter(filter)
+		require.NoError(t, err)
+
+		c := Controller{
+			logger:    zap.NewNop().Sugar(),
+			k8sClient: cl,
+			gerrit:    &gerrit,
+			codebase:  cbService,
+			runtime:   registry.MakeRuntime(&registry.RuntimeConfig{VersionFilter: versionFilter}),
+		}
+
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "registry"}}
+		require.NoError(t, c.reconcile(context.Background(), request))
+
+		var cb codebaseService.Codebase
+		require.NoError(t, cl.Get(context.Background(), request.NamespacedName, &cb))
+
+		return &cb
+	}
+
+	// console of the old version records the new one, but does not process registry anymore
+	cb := reconcileWith("<1.9.5", nil)
+	v, ok := cb.StoredVersion()
+	require.True(t, ok)
+	require.Equal(t, "1.9.5", v.Original())
+
+	// console of the new version takes registry over while stored version is still the old one
+	cbService := mockCodebase.NewServiceInterface(t)
+	cbService.On("GetBranchesByCodebase", mock.Anything, "registry").Return([]codebaseService.CodebaseBranch{}, nil)
+
+	cb = reconcileWith(">=1.9.5", cbService)
+	v, ok = cb.StoredVersion()
+	require.True(t, ok)
+	require.Equal(t, "1.9.5", v.Original())
+}
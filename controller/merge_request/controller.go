@@ -13,7 +13,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/yaml.v3"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,11 +31,17 @@ import (
 	"ddm-admin-console/config"
 	"ddm-admin-console/controller"
 	"ddm-admin-console/controller/codebase"
+	"ddm-admin-console/lifecycle"
+	"ddm-admin-console/logging"
 	codebaseSvc "ddm-admin-console/service/codebase"
 	gerritService "ddm-admin-console/service/gerrit"
 	"ddm-admin-console/service/git"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/gitserver"
 	"ddm-admin-console/service/jenkins"
+	"ddm-admin-console/service/migration"
+	"ddm-admin-console/service/valuesdoc"
+	"ddm-admin-console/tracing"
 )
 
 type Controller struct {
@@ -42,11 +50,13 @@ type Controller struct {
 	k8sClient        client.Client
 	cnf              *config.Settings
 	gerrit           gerritService.ServiceInterface
+	gitProvider      gitprovider.Provider
 	appCache         *cache.Cache
 	codebaseService  codebaseSvc.ServiceInterface
 	gitServerService gitserver.ServiceInterface
 	jenkinsService   jenkins.ServiceInterface
-	versionFilter    *registry.VersionFilter
+	runtime          *registry.Runtime
+	mirrors          *git.MirrorCache
 }
 
 func Make(
@@ -54,10 +64,12 @@ func Make(
 	logger controller.Logger,
 	cnf *config.Settings,
 	gerrit gerritService.ServiceInterface,
+	gitProvider gitprovider.Provider,
 	cbService codebaseSvc.ServiceInterface,
 	gitServerService gitserver.ServiceInterface,
 	jenkinsService jenkins.ServiceInterface,
 	appCache *cache.Cache,
+	runtime *registry.Runtime,
 ) error {
 	c := Controller{
 		mgr:              mgr,
@@ -65,18 +77,15 @@ func Make(
 		k8sClient:        mgr.GetClient(),
 		cnf:              cnf,
 		gerrit:           gerrit,
+		gitProvider:      gitProvider,
 		appCache:         appCache,
 		codebaseService:  cbService,
 		gitServerService: gitServerService,
 		jenkinsService:   jenkinsService,
+		runtime:          runtime,
+		mirrors:          git.MakeMirrorCache(mirrorFolder(cnf)),
 	}
 
-	vf, err := registry.MakeVersionFilter(cnf.RegistryVersionFilter)
-	if err != nil {
-		return fmt.Errorf("unable to init version filter, %w", err)
-	}
-	c.versionFilter = vf
-
 	if err := ctrl.NewControllerManagedBy(mgr).
 		For(
 			&gerritService.GerritMergeRequest{},
@@ -88,7 +97,7 @@ func Make(
 		).
 		WithOptions(
 			k8sController.Options{
-				MaxConcurrentReconciles: 1,
+				MaxConcurrentReconciles: cnf.MergeRequestConcurrentReconciles,
 			},
 		).
 		Complete(&c); err != nil {
@@ -107,11 +116,28 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 }
 
 func (c *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	c.logger.Infow("reconciling merge request", "Request.Namespace", request.Namespace,
-		"Request.Name", request.Name)
+	// reconcile pushes to git, so it is finished at shutdown instead of being interrupted
+	ctx, span := tracing.Start(lifecycle.WithoutCancel(ctx), "merge-request.reconcile",
+		attribute.String("namespace", request.Namespace), attribute.String("name", request.Name))
+
+	// filled from annotations of merge request, so reconcile is logged with fields of console request which created it
+	fields := &logging.Fields{}
+	ctx = logging.WithFields(ctx, fields)
+
+	logFields := func() []interface{} {
+		kvs := append([]interface{}{"Request.Namespace", request.Namespace, "Request.Name", request.Name},
+			fields.KeysAndValues()...)
+
+		return append(kvs, tracing.KeysAndValues(ctx)...)
+	}
+
+	c.logger.Infow("reconciling merge request", logFields()...)
 
-	if err := c.reconcile(ctx, request); err != nil {
-		c.logger.Errorw(err.Error(), "Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	err := c.reconcile(ctx, request)
+	tracing.End(span, err)
+
+	if err != nil {
+		c.logger.Errorw(err.Error(), logFields()...)
 
 		if codebase.IsErrPostpone(err) {
 			return reconcile.Result{RequeueAfter: errors.Unwrap(err).(codebase.ErrPostpone).D()}, nil
@@ -120,12 +146,20 @@ func (c *Controller) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{RequeueAfter: codebase.DefaultRetryTimeout}, nil
 	}
 
-	c.logger.Infow("reconciling merge request done", "Request.Namespace", request.Namespace,
-		"Request.Name", request.Name)
+	c.logger.Infow("reconciling merge request done", logFields()...)
 
 	return reconcile.Result{}, nil
 }
 
+// tracePhase runs reconcile phase in its own span
+func tracePhase(ctx context.Context, name string, phase func(ctx context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "merge-request."+name)
+	err := phase(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
 func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) error {
 	var instance gerritService.GerritMergeRequest
 
@@ -139,12 +173,17 @@ func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) e
 		return fmt.Errorf("unable to get merge request from k8s, err: %w", err)
 	}
 
+	*logging.FromContext(ctx) = *logging.FromAnnotations(instance.Annotations)
+	// merge requests of different projects are reconciled concurrently, of the same project one by one
+	unlock := c.mirrors.Lock(instance.Spec.ProjectName)
+	defer unlock()
+
 	cb, err := c.codebaseService.Get(instance.Spec.ProjectName)
 	if err != nil {
 		return fmt.Errorf("unable to get project codebase, %w", err)
 	}
 
-	processRequest, err := codebase.ProcessRegistryVersion(ctx, c.versionFilter, cb, c.gerrit)
+	processRequest, err := codebase.ProcessRegistryVersion(ctx, c.runtime.Load().VersionFilter, cb, c.gerrit)
 	if err != nil {
 		return fmt.Errorf("unable to p, err: %w", err)
 	}
@@ -156,23 +195,33 @@ func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) e
 		return nil
 	}
 
-	if err := c.prepareMergeRequest(ctx, &instance); err != nil {
+	if err := tracePhase(ctx, "prepare", func(ctx context.Context) error {
+		return c.prepareMergeRequest(ctx, &instance, cb)
+	}); err != nil {
 		return fmt.Errorf("unable to prepare merge request, err: %w", err)
 	}
 
-	if err := c.autoApproveMergeRequest(ctx, &instance); err != nil {
+	if err := tracePhase(ctx, "approve", func(ctx context.Context) error {
+		return c.autoApproveMergeRequest(ctx, &instance)
+	}); err != nil {
 		return fmt.Errorf("unable to approve MR, err: %w", err)
 	}
 
-	if err := c.triggerJobProvisioner(ctx, &instance, cb); err != nil {
+	if err := tracePhase(ctx, "trigger", func(ctx context.Context) error {
+		return c.triggerJobProvisioner(ctx, &instance, cb)
+	}); err != nil {
 		return fmt.Errorf("unable to triggerJobProvisioner MR, err: %w", err)
 	}
 
-	if err := c.addCachedFiles(ctx, &instance); err != nil {
+	if err := tracePhase(ctx, "cache", func(ctx context.Context) error {
+		return c.addCachedFiles(ctx, &instance)
+	}); err != nil {
 		return fmt.Errorf("unable to proceed cached files, %w", err)
 	}
 
-	if err := c.checkBuildJobStatus(ctx, &instance, cb); err != nil {
+	if err := tracePhase(ctx, "build-check", func(ctx context.Context) error {
+		return c.checkBuildJobStatus(ctx, &instance, cb)
+	}); err != nil {
 		return fmt.Errorf("unable to check build status, %w", err)
 	}
 
@@ -323,9 +372,10 @@ func (c *Controller) addCachedFiles(ctx context.Context, instance *gerritService
 		return fmt.Errorf("unable to init git service, %w", err)
 	}
 
-	if err := gitService.Clone(fmt.Sprintf("%s/%s", codebase.GerritSSHURL(c.cnf), instance.Spec.ProjectName)); err != nil {
-		return fmt.Errorf("unable to clone repo, %w", err)
+	if err := c.checkoutProject(gitService, instance.Spec.ProjectName); err != nil {
+		return fmt.Errorf("unable to checkout repo, %w", err)
 	}
+	defer c.removeProjectCheckout(gitService, instance.Spec.ProjectName)
 
 	detail, err := c.gerrit.GetChangeDetails(instance.Status.ChangeID)
 	if err != nil {
@@ -382,8 +432,10 @@ func (c *Controller) autoApproveMergeRequest(ctx context.Context, instance *gerr
 		return nil
 	}
 
-	if err := c.gerrit.ApproveAndSubmitChange(instance.Status.ChangeID, instance.Spec.AuthorName,
-		instance.Spec.AuthorEmail); err != nil {
+	if err := c.gitProvider.ApproveAndSubmitChange(ctx, instance.Status.ChangeID, gitprovider.Author{
+		Name:  instance.Spec.AuthorName,
+		Email: instance.Spec.AuthorEmail,
+	}); err != nil {
 		return fmt.Errorf("unable to approve and submit change, err: %w", err)
 	}
 
@@ -396,7 +448,7 @@ func (c *Controller) autoApproveMergeRequest(ctx context.Context, instance *gerr
 }
 
 // actions
-// 1. clone repo
+// 1. checkout repo worktree from project mirror cache
 // 2. checkout target branch
 // 3. backup values.yaml if it exists
 // 4. checkout source branch
@@ -406,11 +458,13 @@ func (c *Controller) autoApproveMergeRequest(ctx context.Context, instance *gerr
 // 6.1 checkout -b source branch from target branch
 // 7. restore source branch
 // 8. manually merge values.yaml from backup with new version from source branch if it backup`ed
+// 8.1 apply values migrations between target and source branch versions
 // 9. create new change to source branch with new commit
 // 10. apply and submit change
 // 11. set merge request cr spec source branch to pass it to gerrit operator
 // TODO: move this logic to registry upgrade app, to remove MR duplication
-func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritService.GerritMergeRequest) error {
+func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritService.GerritMergeRequest,
+	cb *codebaseSvc.Codebase) error {
 	if instance.Labels[registry.MRLabelAction] != registry.MRLabelActionBranchMerge ||
 		instance.Spec.SourceBranch != "" || instance.Status.ChangeID != "" {
 		c.logger.Infow("nothing need to be done", "Request.Namespace", instance.Namespace,
@@ -433,9 +487,10 @@ func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritSe
 		return fmt.Errorf("unable to get branches from instance labels, err: %w", err)
 	}
 
-	if err := gitService.Clone(fmt.Sprintf("%s/%s", codebase.GerritSSHURL(c.cnf), instance.Spec.ProjectName)); err != nil {
-		return fmt.Errorf("unable to clone repo, err: %w", err)
+	if err := c.checkoutProject(gitService, instance.Spec.ProjectName); err != nil {
+		return fmt.Errorf("unable to checkout repo, err: %w", err)
 	}
+	defer c.removeProjectCheckout(gitService, instance.Spec.ProjectName)
 
 	if err := gitService.RawCheckout(targetBranch, false); err != nil {
 		return fmt.Errorf("unable to checkout branch, err: %w", err)
@@ -475,6 +530,17 @@ func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritSe
 	if err := MergeValuesFiles(valuesBackupPath, projectValuesPath); err != nil {
 		return fmt.Errorf("unable to merge values, err: %w", err)
 	}
+	// migrate values to the structure of new version
+	migrationReport, err := MigrateValuesFile(projectValuesPath, registryVersion(targetBranch, cb),
+		registry.BranchVersion(sourceBranch), migration.Registered)
+	if err != nil {
+		return fmt.Errorf("unable to migrate values, err: %w", err)
+	}
+
+	migrationReportJSON, err := json.Marshal(migrationReport)
+	if err != nil {
+		return fmt.Errorf("unable to encode migration report, err: %w", err)
+	}
 	// add all changes
 	if err := gitService.Add("."); err != nil {
 		return fmt.Errorf("unable to add all files, err: %w", err)
@@ -487,8 +553,8 @@ func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritSe
 
 	if err := gitService.RawCommit(&git.User{Name: instance.Spec.AuthorName, Email: instance.Spec.AuthorEmail},
 		git.CommitMessageWithChangeID(
-			fmt.Sprintf("Add new branch %s\n\nupdate branch values.yaml from [%s] branch", sourceBranch,
-				targetBranch),
+			fmt.Sprintf("Add new branch %s\n\nupdate branch values.yaml from [%s] branch\n\n%s", sourceBranch,
+				targetBranch, migrationReport.String()),
 			changeID)); err != nil && !strings.Contains(err.Error(), "nothing to commit") {
 		return fmt.Errorf("unable to commit changes, %w", err)
 	}
@@ -503,6 +569,10 @@ func (c *Controller) prepareMergeRequest(ctx context.Context, instance *gerritSe
 	}
 
 	reloadInstance.Spec.SourceBranch = sourceBranch
+	if reloadInstance.Annotations == nil {
+		reloadInstance.Annotations = make(map[string]string)
+	}
+	reloadInstance.Annotations[registry.MRAnnotationMigration] = string(migrationReportJSON)
 	reloadInstance.Name = fmt.Sprintf("%s-update-%d", instance.Spec.ProjectName, time.Now().Unix())
 	reloadInstance.ResourceVersion = ""
 	if err := c.k8sClient.Create(ctx, &reloadInstance); err != nil {
@@ -537,6 +607,26 @@ func (c *Controller) initGitService(ctx context.Context, projectPath string) (*g
 	return git.Make(projectPath, c.cnf.GitUsername, privateKey), nil
 }
 
+// checkoutProject checks out project worktree from mirror cache, project lock must be held
+func (c *Controller) checkoutProject(gitService *git.Service, projectName string) error {
+	return gitService.CloneWorktree(c.mirrors.Path(projectName),
+		fmt.Sprintf("%s/%s", codebase.GerritSSHURL(c.cnf), projectName))
+}
+
+func (c *Controller) removeProjectCheckout(gitService *git.Service, projectName string) {
+	if err := gitService.RemoveWorktree(c.mirrors.Path(projectName)); err != nil {
+		c.logger.Errorw("unable to remove project worktree", "project", projectName, "error", err.Error())
+	}
+}
+
+func mirrorFolder(cnf *config.Settings) string {
+	if cnf.GitMirrorFolder != "" {
+		return cnf.GitMirrorFolder
+	}
+
+	return path.Join(cnf.TempFolder, "git-mirrors")
+}
+
 func getBranchesFromLabels(labels map[string]string) (targetBranch, sourceBranch string, err error) {
 	targetBranch, ok := labels[registry.MRLabelTargetBranch]
 	if !ok {
@@ -570,53 +660,91 @@ func prepareControllerFolders(tempFolder, projectName string) (controllerFolderP
 }
 
 func MergeValuesFiles(src, dst string) error {
-	srcFp, err := os.Open(src)
+	srcBts, err := os.ReadFile(src)
 	if err != nil {
-		return fmt.Errorf("unable to open src file, err: %w", err)
+		return fmt.Errorf("unable to read src file, err: %w", err)
 	}
 
-	dstFp, err := os.Open(dst)
+	dstBts, err := os.ReadFile(dst)
 	if err != nil {
-		return fmt.Errorf("unable to open dst file, err: %w", err)
+		return fmt.Errorf("unable to read dst file, err: %w", err)
 	}
 
-	var (
-		srcData map[string]interface{}
-		dstData map[string]interface{}
-	)
-
-	if err := yaml.NewDecoder(srcFp).Decode(&srcData); err != nil {
+	var srcData map[string]interface{}
+	if err := yaml.Unmarshal(srcBts, &srcData); err != nil {
 		return fmt.Errorf("unable to decode src values, err: %w", err)
 	}
 
-	if err := yaml.NewDecoder(dstFp).Decode(&dstData); err != nil {
+	dstDoc, err := valuesdoc.Parse(dstBts)
+	if err != nil {
 		return fmt.Errorf("unable to decode dst values, err: %w", err)
 	}
 
-	if err := srcFp.Close(); err != nil {
-		return fmt.Errorf("unable to close src, err: %w", err)
+	if err := dstDoc.Merge(srcData); err != nil {
+		return fmt.Errorf("unable to merge values, err: %w", err)
 	}
 
-	if err := dstFp.Close(); err != nil {
-		return fmt.Errorf("unable to close dst, err: %w", err)
+	out, err := dstDoc.Bytes()
+	if err != nil {
+		return fmt.Errorf("unable to encode dst data, err: %w", err)
 	}
 
-	out := codebase.MergeMaps(dstData, srcData)
+	if err := os.WriteFile(dst, out, 0644); err != nil {
+		return fmt.Errorf("unable to write dst, err: %w", err)
+	}
+
+	return nil
+}
+
+// registryVersion returns version of registry target branch, effective registry version is used when target
+// is not a version branch, so registries upgraded by merge requests are migrated from their actual version
+func registryVersion(targetBranch string, cb *codebaseSvc.Codebase) *version.Version {
+	v := registry.BranchVersion(targetBranch)
+	if v.Original() != "0" {
+		return v
+	}
+
+	if stored, ok := cb.StoredVersion(); ok {
+		return stored
+	}
 
-	dstFp, err = os.Create(dst)
+	return registry.EffectiveRegistryVersion(cb, nil).Version
+}
+
+func MigrateValuesFile(valuesPath string, from, to *version.Version, migrations migration.Migrations) (*migration.Report, error) {
+	bts, err := os.ReadFile(valuesPath)
 	if err != nil {
-		return fmt.Errorf("unable to recreate dst, err: %w", err)
+		return nil, fmt.Errorf("unable to read values, err: %w", err)
 	}
 
-	if err := yaml.NewEncoder(dstFp).Encode(out); err != nil {
-		return fmt.Errorf("unable to encode dst data, err: %w", err)
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(bts, &values); err != nil {
+		return nil, fmt.Errorf("unable to decode values, err: %w", err)
 	}
 
-	if err := dstFp.Close(); err != nil {
-		return fmt.Errorf("unable to close dst, err: %w", err)
+	if values == nil {
+		values = make(map[string]interface{})
 	}
 
-	return nil
+	report, err := migrations.Apply(values, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply migrations, err: %w", err)
+	}
+
+	if !report.Changed() {
+		return report, nil
+	}
+
+	bts, err = valuesdoc.Apply(bts, values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode values, err: %w", err)
+	}
+
+	if err := os.WriteFile(valuesPath, bts, 0644); err != nil {
+		return nil, fmt.Errorf("unable to write values, err: %w", err)
+	}
+
+	return report, nil
 }
 
 func CopyFolder(src, dst string) error {
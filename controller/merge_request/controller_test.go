@@ -0,0 +1,28 @@
+package merge_request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	codebaseSvc "ddm-admin-console/service/codebase"
+)
+
+func TestRegistryVersion(t *testing.T) {
+	t.Parallel()
+
+	cb := codebaseSvc.Codebase{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			codebaseSvc.VersionAnnotation: "1.9.6",
+		}},
+		Spec: codebaseSvc.CodebaseSpec{BranchToCopyInDefaultBranch: "1.9.3"},
+	}
+
+	require.Equal(t, "1.9.7", registryVersion("1.9.7", &cb).Original())
+	require.Equal(t, "1.9.6", registryVersion("master", &cb).Original(),
+		"effective version is used instead of the branch registry was created from")
+
+	delete(cb.Annotations, codebaseSvc.VersionAnnotation)
+	require.Equal(t, "1.9.3", registryVersion("master", &cb).Original())
+}
@@ -0,0 +1,47 @@
+package permissions_invalidation
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"ddm-admin-console/controller"
+	"ddm-admin-console/service/permissions"
+)
+
+// Controller drops cached user permissions when roles or role bindings of console namespace change.
+// Cluster roles are not watched, their changes are picked up when cached rules expire.
+type Controller struct {
+	logger controller.Logger
+	perms  permissions.ServiceInterface
+}
+
+func Make(mgr ctrl.Manager, logger controller.Logger, perms permissions.ServiceInterface) error {
+	c := Controller{
+		logger: logger,
+		perms:  perms,
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("permissions-invalidation").
+		For(&rbacv1.RoleBinding{}).
+		Watches(&source.Kind{Type: &rbacv1.Role{}}, &handler.EnqueueRequestForObject{}).
+		Complete(&c); err != nil {
+		return fmt.Errorf("unable to create controller, %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+	c.perms.Invalidate()
+	c.logger.Infow("user permissions invalidated", "Request.Namespace", request.Namespace,
+		"Request.Name", request.Name)
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,196 @@
+package registry_deletion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/config"
+	"ddm-admin-console/controller"
+	"ddm-admin-console/controller/codebase"
+	codebaseService "ddm-admin-console/service/codebase"
+)
+
+const (
+	ReportLabel     = "registry-deletion-report"
+	reportDataIndex = "report"
+)
+
+type Controller struct {
+	logger    controller.Logger
+	k8sClient client.Client
+	reader    client.Reader
+	cnf       *config.Settings
+	codebase  codebaseService.ServiceInterface
+	cleaner   *registry.Cleaner
+}
+
+func Make(mgr ctrl.Manager, logger controller.Logger, cnf *config.Settings,
+	cbService codebaseService.ServiceInterface, cleaner *registry.Cleaner) error {
+	c := Controller{
+		logger:    logger,
+		k8sClient: mgr.GetClient(),
+		reader:    mgr.GetAPIReader(),
+		cnf:       cnf,
+		codebase:  cbService,
+		cleaner:   cleaner,
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("registry-deletion").
+		For(&codebaseService.Codebase{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: isDeletionRequestUpdated})).
+		Complete(&c); err != nil {
+		return fmt.Errorf("unable to create controller, %w", err)
+	}
+
+	return nil
+}
+
+func isDeletionRequestUpdated(e event.UpdateEvent) bool {
+	oo := e.ObjectOld.(*codebaseService.Codebase)
+	no := e.ObjectNew.(*codebaseService.Codebase)
+
+	return oo.Annotations[codebaseService.DeletionRequestedAtAnnotation] !=
+		no.Annotations[codebaseService.DeletionRequestedAtAnnotation]
+}
+
+func ReportConfigMapName(registryName string) string {
+	return fmt.Sprintf("registry-deletion-report-%s", registryName)
+}
+
+func (c *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if err := c.reconcile(ctx, request); err != nil {
+		c.logger.Errorw(err.Error(), "Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+		if codebase.IsErrPostpone(err) {
+			return reconcile.Result{RequeueAfter: errors.Unwrap(err).(codebase.ErrPostpone).D()}, nil
+		}
+
+		return reconcile.Result{RequeueAfter: codebase.DefaultRetryTimeout}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (c *Controller) reconcile(ctx context.Context, request reconcile.Request) error {
+	var instance codebaseService.Codebase
+	if err := c.k8sClient.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to get codebase from k8s, %w", err)
+	}
+
+	if instance.Spec.Type != codebaseService.RegistryCodebaseType || instance.ForegroundDeletion() {
+		return nil
+	}
+
+	requestedAt, ok := instance.DeletionRequestedAt()
+	if !ok {
+		return nil
+	}
+
+	// annotations are writable by anyone who can update codebase, so cleanup requires deletion request
+	// written by console and retention is counted from its creation time which is set by api server
+	var deletionRequest v1.Secret
+	if err := c.reader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace,
+		Name: registry.DeletionRequestName(instance.Name)}, &deletionRequest); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			c.logger.Infow("registry deletion is not requested through console, skipping cleanup",
+				"Request.Namespace", request.Namespace, "Request.Name", request.Name)
+			return nil
+		}
+
+		return fmt.Errorf("unable to get deletion request, %w", err)
+	}
+
+	if created := deletionRequest.CreationTimestamp.Time; created.After(requestedAt) {
+		requestedAt = created
+	}
+
+	if wait := time.Until(requestedAt.Add(c.cnf.RegistryDeletionRetention)); wait > 0 {
+		return fmt.Errorf("registry is in retention window, %w", codebase.ErrPostpone(wait))
+	}
+
+	c.logger.Infow("cleaning up registry", "Request.Namespace", request.Namespace,
+		"Request.Name", request.Name)
+
+	report := c.cleaner.Cleanup(ctx, instance.Name)
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("unable to encode deletion report, %w", err)
+	}
+
+	if report.Failed() {
+		instance.Annotations[codebaseService.DeletionReportAnnotation] = string(reportJSON)
+		if err := c.codebase.Update(ctx, &instance); err != nil {
+			return fmt.Errorf("unable to save deletion report, %w", err)
+		}
+
+		return fmt.Errorf("registry cleanup failed, report: %s", string(reportJSON))
+	}
+
+	if err := c.saveReport(ctx, &instance, registry.DeletionRequestedBy(&deletionRequest), string(reportJSON)); err != nil {
+		return fmt.Errorf("unable to save deletion report, %w", err)
+	}
+
+	if err := c.codebase.Delete(instance.Name); err != nil {
+		return fmt.Errorf("unable to delete codebase, %w", err)
+	}
+
+	if err := c.k8sClient.Delete(ctx, &deletionRequest); err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete deletion request, %w", err)
+	}
+
+	c.logger.Infow("registry deleted", "Request.Namespace", request.Namespace,
+		"Request.Name", request.Name, "report", string(reportJSON))
+
+	return nil
+}
+
+func (c *Controller) saveReport(ctx context.Context, instance *codebaseService.Codebase, requestedBy, report string) error {
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ReportConfigMapName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				ReportLabel: instance.Name,
+			},
+			Annotations: map[string]string{
+				codebaseService.DeletionRequestedByAnnotation: requestedBy,
+			},
+		},
+		Data: map[string]string{
+			reportDataIndex: report,
+		},
+	}
+
+	if err := c.k8sClient.Create(ctx, &cm); err != nil {
+		if !k8sErrors.IsAlreadyExists(err) {
+			return fmt.Errorf("unable to create config map, %w", err)
+		}
+
+		if err := c.k8sClient.Update(ctx, &cm); err != nil {
+			return fmt.Errorf("unable to update config map, %w", err)
+		}
+	}
+
+	return nil
+}
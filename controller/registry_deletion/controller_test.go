@@ -0,0 +1,176 @@
+package registry_deletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	pkgScheme "sigs.k8s.io/controller-runtime/pkg/scheme"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/config"
+	"ddm-admin-console/controller/codebase"
+	mockCodebase "ddm-admin-console/mocks/codebase"
+	mockKeycloak "ddm-admin-console/mocks/keycloak"
+	mockPermissions "ddm-admin-console/mocks/permissions"
+	mockVault "ddm-admin-console/mocks/vault"
+	codebaseService "ddm-admin-console/service/codebase"
+	"ddm-admin-console/service/keycloak"
+)
+
+const (
+	testNamespace = "control-plane"
+	testRegistry  = "reg-1"
+)
+
+type testEnv struct {
+	controller *Controller
+	client     client.Client
+	codebase   *mockCodebase.ServiceInterface
+	vault      *mockVault.ServiceInterface
+}
+
+func makeTestEnv(t *testing.T, annotatedAt time.Time, request *v1.Secret) *testEnv {
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+
+	builder := pkgScheme.Builder{GroupVersion: schema.GroupVersion{Group: "v2.edp.epam.com", Version: "v1alpha1"}}
+	builder.Register(&codebaseService.Codebase{}, &codebaseService.CodebaseList{})
+	require.NoError(t, builder.AddToScheme(sch))
+
+	objects := []client.Object{&codebaseService.Codebase{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testRegistry,
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				codebaseService.DeletionRequestedAtAnnotation: annotatedAt.UTC().Format(time.RFC3339),
+				codebaseService.DeletionRequestedByAnnotation: "intruder@example.com",
+			},
+		},
+		Spec: codebaseService.CodebaseSpec{Type: codebaseService.RegistryCodebaseType},
+	}}
+	if request != nil {
+		objects = append(objects, request)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(objects...).Build()
+
+	vaultService := mockVault.ServiceInterface{}
+	keycloakService := mockKeycloak.ServiceInterface{}
+	keycloakService.On("GetUsersByRealm", mock.Anything, "admins").Return([]keycloak.KeycloakRealmUser{}, nil)
+	perms := mockPermissions.ServiceInterface{}
+	perms.On("DeleteRegistry", testRegistry)
+	cbService := mockCodebase.ServiceInterface{}
+
+	return &testEnv{
+		controller: &Controller{
+			logger:    zap.NewNop().Sugar(),
+			k8sClient: cl,
+			reader:    cl,
+			cnf:       &config.Settings{RegistryDeletionRetention: time.Hour},
+			codebase:  &cbService,
+			cleaner: registry.MakeCleaner(&vaultService, &keycloakService, &perms, cache.New(time.Minute, time.Minute),
+				registry.Config{
+					UsersRealm:                      "admins",
+					VaultKVEngineName:               "registry-kv",
+					VaultRegistrySecretPathTemplate: "{engine}/registry/{registry}",
+				}),
+		},
+		client:   cl,
+		codebase: &cbService,
+		vault:    &vaultService,
+	}
+}
+
+func deletionRequest(createdAt time.Time) *v1.Secret {
+	request := registry.MakeDeletionRequest(testRegistry, "admin@example.com")
+	request.Namespace = testNamespace
+	request.CreationTimestamp = metav1.NewTime(createdAt)
+
+	return request
+}
+
+func reconcileRequest() reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testRegistry}}
+}
+
+func TestController_AnnotationWithoutRequestIsIgnored(t *testing.T) {
+	t.Parallel()
+
+	env := makeTestEnv(t, time.Now().Add(-100*time.Hour), nil)
+
+	require.NoError(t, env.controller.reconcile(context.Background(), reconcileRequest()))
+	env.codebase.AssertNotCalled(t, "Delete", mock.Anything)
+	env.vault.AssertNotCalled(t, "DeleteAll", mock.Anything)
+}
+
+func TestController_RetentionIsCountedFromRequest(t *testing.T) {
+	t.Parallel()
+
+	env := makeTestEnv(t, time.Now().Add(-100*time.Hour), deletionRequest(time.Now().Add(-10*time.Minute)))
+
+	err := env.controller.reconcile(context.Background(), reconcileRequest())
+	require.Error(t, err)
+	require.True(t, codebase.IsErrPostpone(err))
+
+	var postpone codebase.ErrPostpone
+	require.True(t, errors.As(err, &postpone))
+	require.InDelta(t, 50*time.Minute, postpone.D(), float64(time.Minute))
+
+	env.codebase.AssertNotCalled(t, "Delete", mock.Anything)
+	env.vault.AssertNotCalled(t, "DeleteAll", mock.Anything)
+}
+
+func TestController_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	env := makeTestEnv(t, time.Now().Add(-2*time.Hour), deletionRequest(time.Now().Add(-2*time.Hour)))
+	env.vault.On("DeleteAll", "registry-kv/registry/"+testRegistry).Return(nil)
+	env.codebase.On("Delete", testRegistry).Return(nil)
+
+	require.NoError(t, env.controller.reconcile(context.Background(), reconcileRequest()))
+	env.codebase.AssertExpectations(t)
+	env.vault.AssertExpectations(t)
+
+	var report v1.ConfigMap
+	require.NoError(t, env.client.Get(context.Background(), types.NamespacedName{Namespace: testNamespace,
+		Name: ReportConfigMapName(testRegistry)}, &report))
+	require.Equal(t, "admin@example.com", report.Annotations[codebaseService.DeletionRequestedByAnnotation],
+		"requester is taken from deletion request, not from codebase annotation")
+	require.Contains(t, report.Data[reportDataIndex], registry.DeletionStepVault)
+
+	err := env.client.Get(context.Background(), types.NamespacedName{Namespace: testNamespace,
+		Name: registry.DeletionRequestName(testRegistry)}, &v1.Secret{})
+	require.True(t, k8sErrors.IsNotFound(err))
+}
+
+func TestController_FailedCleanupKeepsRegistry(t *testing.T) {
+	t.Parallel()
+
+	env := makeTestEnv(t, time.Now().Add(-2*time.Hour), deletionRequest(time.Now().Add(-2*time.Hour)))
+	env.vault.On("DeleteAll", mock.Anything).Return(errors.New("vault is sealed"))
+	env.codebase.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	err := env.controller.reconcile(context.Background(), reconcileRequest())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "vault is sealed")
+
+	env.codebase.AssertNotCalled(t, "Delete", mock.Anything)
+	cb := env.codebase.Calls[0].Arguments.Get(1).(*codebaseService.Codebase)
+	require.Contains(t, cb.Annotations[codebaseService.DeletionReportAnnotation], "vault is sealed")
+}
@@ -0,0 +1,102 @@
+package runtime_config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"ddm-admin-console/app/registry"
+	"ddm-admin-console/config"
+	"ddm-admin-console/controller"
+)
+
+const (
+	eventReasonReloaded     = "RuntimeConfigReloaded"
+	eventReasonReloadFailed = "RuntimeConfigReloadFailed"
+)
+
+// Controller reloads runtime config of apps and controllers from config map, keys of config map are names of
+// reloadable settings, settings missing in config map keep values console was started with.
+// Reloads are logged and recorded as events of config map, invalid config map leaves current config in place.
+type Controller struct {
+	logger    controller.Logger
+	k8sClient client.Client
+	recorder  record.EventRecorder
+	cnf       *config.Settings
+	runtime   *registry.Runtime
+}
+
+func Make(mgr ctrl.Manager, logger controller.Logger, cnf *config.Settings, runtime *registry.Runtime) error {
+	c := Controller{
+		logger:    logger,
+		k8sClient: mgr.GetClient(),
+		recorder:  mgr.GetEventRecorderFor("admin-console"),
+		cnf:       cnf,
+		runtime:   runtime,
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("runtime-config").
+		For(&v1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == cnf.RuntimeConfigMap
+		}))).
+		Complete(&c); err != nil {
+		return fmt.Errorf("unable to create controller, %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	var cm v1.ConfigMap
+
+	if err := c.k8sClient.Get(ctx, request.NamespacedName, &cm); err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("unable to get runtime config map, %w", err)
+		}
+		// deleted config map returns settings console was started with
+		cm.Data = nil
+	}
+
+	rc, err := c.cnf.RuntimeConfig(cm.Data)
+	if err != nil {
+		// config map has to be fixed, so reconcile is not repeated
+		c.logger.Errorw("unable to reload runtime config", "configMap", request.Name, "error", err.Error())
+
+		if cm.UID != "" {
+			c.recorder.Eventf(&cm, v1.EventTypeWarning, eventReasonReloadFailed, "runtime config is not reloaded, %s", err)
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	changes := config.RuntimeChanges(c.runtime.Load(), rc)
+	if len(changes) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	c.runtime.Store(rc)
+
+	msgs := make([]string, 0, len(changes))
+	for _, ch := range changes {
+		msgs = append(msgs, ch.String())
+	}
+
+	c.logger.Infow("runtime config reloaded", "configMap", request.Name, "changes", msgs)
+
+	if cm.UID != "" {
+		c.recorder.Eventf(&cm, v1.EventTypeNormal, eventReasonReloaded, "runtime config reloaded, %s",
+			strings.Join(msgs, ", "))
+	}
+
+	return reconcile.Result{}, nil
+}
@@ -3,6 +3,7 @@ import { createVuetify } from 'vuetify';
 import App from './App.vue';
 import router from './router';
 import i18n from './localization';
+import { installCSRFProtection } from './utils/csrf';
 
 import './assets/main.css';
 import '@mdi/font/css/materialdesignicons.css';
@@ -15,6 +16,7 @@ const templateVariables = JSON.parse(templateEl?.getAttribute('data-args') || '{
 const environmentVariables = JSON.parse(envEl?.getAttribute('data-args') || '{}');
 
 i18n.global.locale  = environmentVariables.language || 'uk';
+installCSRFProtection();
 
 app.use(router);
 app.use(i18n);
@@ -7,6 +7,8 @@ import EditCluster from '@/views/cluster/EditView.vue';
 import ManagementView from '@/views/cluster/ManagementView.vue';
 import RegistryList from '@/views/registry/RegistryList.vue';
 const ChangeView = () => import('@/views/ChangeView.vue');
+const AccessDiagnosticsView = () => import('@/views/AccessDiagnosticsView.vue');
+const SessionsView = () => import('@/views/SessionsView.vue');
 
 const router = createRouter({
   history: createWebHistory(import.meta.env.BASE_URL),
@@ -51,6 +53,16 @@ const router = createRouter({
       name: 'change',
       component: ChangeView
     },
+    {
+      path: '/admin/access-diagnostics',
+      name: 'access-diagnostics',
+      component: AccessDiagnosticsView
+    },
+    {
+      path: '/admin/sessions',
+      name: 'sessions',
+      component: SessionsView
+    },
   ]
 });
 
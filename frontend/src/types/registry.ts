@@ -53,6 +53,7 @@ export interface RegistryTemplateVariables {
   gerritBranches: string[];
   platformVersion: string;
   previousVersion: string;
+  versionFilter: string;
 }
 
 export interface RegistryAdmin {
@@ -103,6 +104,7 @@ export interface CitizenAuthFlow {
 
 export interface RegistryWizardTemplateVariables {
   error?: any
+  valuesErrors?: string[]
   action: 'edit' | 'create'
   dnsManual: string
   hasUpdate: boolean
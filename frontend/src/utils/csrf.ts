@@ -0,0 +1,57 @@
+import axios from 'axios';
+
+export const CSRF_HEADER = 'X-CSRF-Token';
+export const CSRF_FIELD = 'csrf_token';
+
+export const getCSRFToken = (): string => {
+  return document.querySelector('meta[name="csrf-token"]')?.getAttribute('content') || '';
+};
+
+const addCSRFField = (form: HTMLFormElement) => {
+  if (form.method.toLowerCase() !== 'post' || form.querySelector(`input[name="${CSRF_FIELD}"]`)) {
+    return;
+  }
+
+  const input = document.createElement('input');
+  input.type = 'hidden';
+  input.name = CSRF_FIELD;
+  input.value = getCSRFToken();
+  form.appendChild(input);
+};
+
+// installCSRFProtection adds session csrf token to axios requests and to every posted form,
+// forms submitted from code do not fire submit event, so form submit is wrapped as well
+export const installCSRFProtection = () => {
+  axios.defaults.headers.common[CSRF_HEADER] = getCSRFToken();
+
+  document.addEventListener('submit', (event) => {
+    if (event.target instanceof HTMLFormElement) {
+      addCSRFField(event.target);
+    }
+  }, true);
+
+  const submit = HTMLFormElement.prototype.submit;
+  HTMLFormElement.prototype.submit = function () {
+    addCSRFField(this);
+    submit.call(this);
+  };
+};
+
+// submitPost navigates to url with form post, it replaces links to actions which change state
+export const submitPost = (url: string, fields: Record<string, string> = {}) => {
+  const form = document.createElement('form');
+  form.method = 'post';
+  form.action = url;
+
+  Object.entries(fields).forEach(([name, value]) => {
+    const input = document.createElement('input');
+    input.type = 'hidden';
+    input.name = name;
+    input.value = value;
+    form.appendChild(input);
+  });
+
+  addCSRFField(form);
+  document.body.appendChild(form);
+  form.submit();
+};
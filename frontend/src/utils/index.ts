@@ -1,4 +1,5 @@
 export * from './common';
+export * from './csrf';
 export * from './date';
 export * from './errors';
 export * from './mergeRequest';
\ No newline at end of file
@@ -0,0 +1,175 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+type Logger interface {
+	Infow(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type step struct {
+	name string
+	stop func(ctx context.Context) error
+	// done is closed when run function of component returns, nil for plain shutdown steps
+	done chan struct{}
+}
+
+// Manager runs long-living components of console and stops them in reverse order of registration
+// on SIGINT, SIGTERM or when one of components fails, all steps share one shutdown deadline
+type Manager struct {
+	logger  Logger
+	timeout time.Duration
+
+	mu     sync.Mutex
+	steps  []step
+	failed chan error
+}
+
+func Make(logger Logger, timeout time.Duration) *Manager {
+	return &Manager{
+		logger:  logger,
+		timeout: timeout,
+		failed:  make(chan error, 1),
+	}
+}
+
+// Run starts blocking component, at shutdown stop is called and manager waits until run returns,
+// run returning before shutdown starts shutdown of all components
+func (m *Manager) Run(name string, run func() error, stop func(ctx context.Context) error) {
+	done := make(chan struct{})
+
+	m.add(step{name: name, stop: stop, done: done})
+
+	go func() {
+		defer close(done)
+
+		if err := run(); err != nil {
+			m.fail(fmt.Errorf("%s failed, %w", name, err))
+			return
+		}
+
+		m.fail(fmt.Errorf("%s stopped unexpectedly", name))
+	}()
+}
+
+// OnShutdown registers step which is called after components registered after it are stopped
+func (m *Manager) OnShutdown(name string, stop func(ctx context.Context) error) {
+	m.add(step{name: name, stop: stop})
+}
+
+func (m *Manager) add(s step) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.steps = append(m.steps, s)
+}
+
+func (m *Manager) fail(err error) {
+	select {
+	case m.failed <- err:
+	default:
+	}
+}
+
+// Wait blocks until shutdown signal or failure of component and then stops everything,
+// returned error contains failure of component and errors of shutdown steps
+func (m *Manager) Wait() error {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	var cause error
+
+	select {
+	case sig := <-sigs:
+		m.logger.Infow("shutdown signal received", "signal", sig.String())
+	case cause = <-m.failed:
+		m.logger.Errorw("component failed, shutting down", "error", cause.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	return multierr.Append(cause, m.Shutdown(ctx))
+}
+
+// Shutdown stops components in reverse order of registration until ctx deadline
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	steps := m.steps
+	m.mu.Unlock()
+
+	var errs error
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		start := time.Now()
+		m.logger.Infow("stopping", "component", s.name)
+
+		if err := m.stop(ctx, s); err != nil {
+			m.logger.Errorw("unable to stop", "component", s.name, "error", err.Error())
+			errs = multierr.Append(errs, fmt.Errorf("unable to stop %s, %w", s.name, err))
+
+			continue
+		}
+
+		m.logger.Infow("stopped", "component", s.name, "duration", time.Since(start).String())
+	}
+
+	m.logger.Infow("shutdown complete")
+
+	return errs
+}
+
+func (m *Manager) stop(ctx context.Context, s step) error {
+	if err := s.stop(ctx); err != nil {
+		return err
+	}
+
+	if s.done == nil {
+		return nil
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithoutCancel returns context with values of parent which is not canceled with parent,
+// it lets operations started before shutdown finish instead of being interrupted midway
+func WithoutCancel(parent context.Context) context.Context {
+	return withoutCancel{parent: parent}
+}
+
+type withoutCancel struct {
+	parent context.Context
+}
+
+func (withoutCancel) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancel) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancel) Err() error {
+	return nil
+}
+
+func (c withoutCancel) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,77 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	m := Make(zap.NewNop().Sugar(), time.Second)
+
+	var order []string
+	m.OnShutdown("cache", func(context.Context) error {
+		order = append(order, "cache")
+		return nil
+	})
+
+	stopServer := make(chan struct{})
+	m.Run("server", func() error {
+		<-stopServer
+		// in-flight work finishes after stop is requested
+		time.Sleep(10 * time.Millisecond)
+		order = append(order, "server drained")
+
+		return nil
+	}, func(context.Context) error {
+		order = append(order, "server")
+		close(stopServer)
+
+		return nil
+	})
+
+	m.Run("broken", func() error {
+		return errors.New("port is in use")
+	}, func(context.Context) error {
+		return nil
+	})
+
+	err := m.Wait()
+	require.ErrorContains(t, err, "broken failed, port is in use")
+	require.Equal(t, []string{"server", "server drained", "cache"}, order)
+}
+
+func TestManager_ShutdownDeadline(t *testing.T) {
+	t.Parallel()
+
+	m := Make(zap.NewNop().Sugar(), time.Second)
+	m.Run("stuck", func() error {
+		select {}
+	}, func(context.Context) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, m.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestWithoutCancel(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx := WithoutCancel(parent)
+	cancel()
+
+	require.NoError(t, ctx.Err())
+	require.Equal(t, "value", ctx.Value(key{}))
+}
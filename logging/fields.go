@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const (
+	RequestIDAnnotation = "request-context/request-id"
+	UserAnnotation      = "request-context/user"
+	RegistryAnnotation  = "request-context/registry"
+)
+
+type fieldsKey struct{}
+
+// Fields identify console request which caused an action, they are logged by handlers and by controller
+// reconciles of objects created during the request
+type Fields struct {
+	RequestID string
+	User      string
+	Registry  string
+}
+
+// WithFields returns context carrying fields, fields are shared by pointer, so values filled later
+// during request are visible to all derived contexts
+func WithFields(ctx context.Context, f *Fields) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// FromContext returns fields of context or empty fields
+func FromContext(ctx context.Context) *Fields {
+	if f, ok := ctx.Value(fieldsKey{}).(*Fields); ok && f != nil {
+		return f
+	}
+
+	return &Fields{}
+}
+
+// FromAnnotations restores fields of request which created an object
+func FromAnnotations(annotations map[string]string) *Fields {
+	return &Fields{
+		RequestID: annotations[RequestIDAnnotation],
+		User:      annotations[UserAnnotation],
+		Registry:  annotations[RegistryAnnotation],
+	}
+}
+
+// Annotate returns copy of object annotations with non-empty fields added, existing annotations are kept
+func (f *Fields) Annotate(annotations map[string]string) map[string]string {
+	result := make(map[string]string, len(annotations)+3)
+	for k, v := range annotations {
+		result[k] = v
+	}
+
+	for k, v := range map[string]string{
+		RequestIDAnnotation: f.RequestID,
+		UserAnnotation:      f.User,
+		RegistryAnnotation:  f.Registry,
+	} {
+		if _, ok := result[k]; !ok && v != "" {
+			result[k] = v
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// Zap returns non-empty fields for log entries
+func (f *Fields) Zap() []zap.Field {
+	var fields []zap.Field
+
+	for _, kv := range f.keysAndValues() {
+		fields = append(fields, zap.String(kv[0], kv[1]))
+	}
+
+	return fields
+}
+
+// KeysAndValues returns non-empty fields for sugared log entries
+func (f *Fields) KeysAndValues() []interface{} {
+	var kvs []interface{}
+
+	for _, kv := range f.keysAndValues() {
+		kvs = append(kvs, kv[0], kv[1])
+	}
+
+	return kvs
+}
+
+func (f *Fields) keysAndValues() [][2]string {
+	var kvs [][2]string
+
+	for _, kv := range [][2]string{{"request_id", f.RequestID}, {"user", f.User}, {"registry", f.Registry}} {
+		if kv[1] != "" {
+			kvs = append(kvs, kv)
+		}
+	}
+
+	return kvs
+}
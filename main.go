@@ -10,12 +10,10 @@ import (
 	"html/template"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"sort"
 	"time"
 
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -25,18 +23,25 @@ import (
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/oauth2"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlCache "sigs.k8s.io/controller-runtime/pkg/cache"
 
 	"ddm-admin-console/app/cluster"
 	"ddm-admin-console/app/dashboard"
 	"ddm-admin-console/app/registry"
 	oauth "ddm-admin-console/auth"
 	"ddm-admin-console/config"
+	adminExpiryController "ddm-admin-console/controller/admin_expiry"
 	codebaseController "ddm-admin-console/controller/codebase"
 	mergeRequestController "ddm-admin-console/controller/merge_request"
+	permissionsInvalidationController "ddm-admin-console/controller/permissions_invalidation"
+	registryDeletionController "ddm-admin-console/controller/registry_deletion"
+	runtimeConfigController "ddm-admin-console/controller/runtime_config"
+	"ddm-admin-console/lifecycle"
 	"ddm-admin-console/locale"
 	"ddm-admin-console/mocks"
 	mockDashboard "ddm-admin-console/mocks/dashboard"
@@ -44,13 +49,23 @@ import (
 	"ddm-admin-console/service/codebase"
 	edpComponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/gitserver"
 	"ddm-admin-console/service/jenkins"
 	"ddm-admin-console/service/k8s"
 	"ddm-admin-console/service/keycloak"
 	"ddm-admin-console/service/openshift"
 	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/roles"
+	"ddm-admin-console/service/session"
 	"ddm-admin-console/service/vault"
+	"ddm-admin-console/tracing"
+)
+
+const (
+	sessionCookieName     = "console-session"
+	tracingServiceName    = "ddm-admin-console"
+	validateConfigCommand = "validate-config"
 )
 
 var (
@@ -60,20 +75,33 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == validateConfigCommand {
+		os.Exit(validateConfig(os.Args[2:]))
+	}
+
 	flag.StringVar(&configPath, "c", "default.env", "config file path")
 	flag.StringVar(&cachePath, "ch", "cache.db", "cache file path")
 	flag.Parse()
 
-	cnf, err := loadConfig(configPath)
+	cnf, fileKeys, err := loadConfig(configPath)
 	if err != nil {
 		panic(err)
 	}
 
+	problems := cnf.Check(fileKeys, config.EnvKeys(os.Environ()))
+	if err := problems.Err(); err != nil {
+		panic(fmt.Sprintf("invalid configuration, run %s for details, %s", validateConfigCommand, err))
+	}
+
 	logger, err := getLogger(cnf.LogLevel, cnf.LogEncoding)
 	if err != nil {
 		panic(err)
 	}
 
+	for _, p := range problems {
+		logger.Warn("configuration problem", zap.String("key", p.Key), zap.String("problem", p.Message))
+	}
+
 	envVariables := gin.H{
 		"language": os.Getenv("LANGUAGE"),
 		"region":   cnf.Region,
@@ -94,36 +122,50 @@ func main() {
 		"platform", buildInfo.Platform,
 	)
 
+	lc := lifecycle.Make(logger.Sugar(), cnf.ShutdownTimeout)
+
+	tracingShutdown, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    cnf.TracingExporter,
+		ServiceName: tracingServiceName,
+		Version:     buildInfo.Version,
+		SampleRatio: cnf.TracingSampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
+	lc.OnShutdown("tracing", tracingShutdown)
+
 	router.ConsoleVersion = buildInfo.Version
 	logger.Info("init gin router")
 	gin.SetMode(cnf.GinMode)
 	r := gin.New()
+	// handlers pass gin context to clients, fallback makes request span and cancellation visible to them
+	r.ContextWithFallback = true
+	r.Use(tracing.GinMiddleware(tracingServiceName))
 	r.SetFuncMap(template.FuncMap{"i18n": locale.Localize, "majorVersion": majorVersion, "envVars": getEnvVars})
 	r.LoadHTMLGlob("templates/**/*")
 	r.Static("/static", "./static")
 	r.Static("/assets", "./frontend/dist/assets")
-	store := cookie.NewStore([]byte(cnf.SessionSecret))
-	r.Use(sessions.Sessions("cookie-session", store))
-
 	logger.Info("init apps")
-	if err := initApps(logger, cnf, r, buildInfo.Date()); err != nil {
+	if err := initApps(logger, cnf, r, buildInfo.Date(), lc); err != nil {
 		panic(fmt.Sprintf("%+v", err))
 	}
 
 	logger.Info("run router on port", zap.String("port", cnf.HTTPPort))
-	if err := r.Run(fmt.Sprintf(":%s", cnf.HTTPPort)); err != nil {
-		panic(err)
-	}
-}
+	srv := &http.Server{Addr: fmt.Sprintf(":%s", cnf.HTTPPort), Handler: r}
+	// registered last, so it stops accepting requests and drains in-flight handlers before anything else stops
+	lc.Run("http server", func() error {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
 
-func exitWait(sigs chan os.Signal, appCache *cache.Cache) {
-	<-sigs
+		return nil
+	}, srv.Shutdown)
 
-	if err := saveCache(appCache); err != nil {
-		panic(err)
+	if err := lc.Wait(); err != nil {
+		logger.Error("console stopped with errors", zap.Error(err))
+		os.Exit(1)
 	}
-
-	os.Exit(0)
 }
 
 func saveCache(appCache *cache.Cache) error {
@@ -134,17 +176,64 @@ func saveCache(appCache *cache.Cache) error {
 	return nil
 }
 
-func loadConfig(path string) (*config.Settings, error) {
+// loadConfig loads config file into env and parses settings, keys of file are returned for config check
+func loadConfig(path string) (*config.Settings, []string, error) {
+	fileEnv, err := godotenv.Read(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read config file, %w", err)
+	}
+
 	if err := godotenv.Load(path); err != nil {
-		return nil, fmt.Errorf("unable to load config file, %w", err)
+		return nil, nil, fmt.Errorf("unable to load config file, %w", err)
 	}
 
 	var cnf config.Settings
 	if err := envconfig.Process("", &cnf); err != nil {
-		return nil, fmt.Errorf("unable to parse env variables, %w", err)
+		return nil, nil, fmt.Errorf("unable to parse env variables, %w", err)
+	}
+
+	fileKeys := make([]string, 0, len(fileEnv))
+	for k := range fileEnv {
+		fileKeys = append(fileKeys, k)
+	}
+	sort.Strings(fileKeys)
+
+	return &cnf, fileKeys, nil
+}
+
+// validateConfig prints effective configuration with redacted secrets and its problems,
+// exit code is not zero if console would refuse to start with the configuration
+func validateConfig(args []string) int {
+	fs := flag.NewFlagSet(validateConfigCommand, flag.ExitOnError)
+	path := fs.String("c", "default.env", "config file path")
+	_ = fs.Parse(args)
+
+	cnf, fileKeys, err := loadConfig(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
 
-	return &cnf, nil
+	for _, s := range cnf.Effective() {
+		fmt.Printf("%s=%s\n", s.Key, s.Value)
+	}
+
+	problems := cnf.Check(fileKeys, config.EnvKeys(os.Environ()))
+	if len(problems) > 0 {
+		fmt.Println()
+	}
+
+	for _, p := range problems {
+		fmt.Println(p.String())
+	}
+
+	if err := problems.Err(); err != nil {
+		return 1
+	}
+
+	fmt.Println("\nconfiguration is valid")
+
+	return 0
 }
 
 func getEnvVars() string {
@@ -193,6 +282,7 @@ func initServices(
 	restConf *rest.Config,
 	appConf *config.Settings,
 	logger *zap.Logger,
+	lc *lifecycle.Manager,
 ) (
 	*config.Services,
 	error,
@@ -209,16 +299,20 @@ func initServices(
 		return nil, fmt.Errorf("unable to init edp component service, %w", err)
 	}
 
-	serviceItems.Codebase, err = codebase.Make(sch, restConf, appConf.Namespace)
+	codebaseService, err := codebase.Make(sch, restConf, appConf.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to init codebase service, %w", err)
 	}
+	serviceItems.Codebase = codebaseService
 
 	serviceItems.K8S, err = k8s.Make(restConf, appConf.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to init k8s service, %w", err)
 	}
 
+	serviceItems.Sessions = session.Make(sessionBackend(appConf, serviceItems.K8S),
+		[]byte(appConf.SessionSecret), appConf.SessionConfig())
+
 	serviceItems.Jenkins, err = jenkins.Make(
 		sch,
 		restConf,
@@ -227,6 +321,7 @@ func initServices(
 			Namespace:       appConf.Namespace,
 			APIUrl:          appConf.JenkinsAPIURL,
 			AdminSecretName: appConf.JenkinsAdminSecretName,
+			ClientPolicy:    appConf.ClientPolicy(),
 		},
 	)
 	if err != nil {
@@ -238,24 +333,39 @@ func initServices(
 		return nil, fmt.Errorf("unable to init open shift service, %w", err)
 	}
 
-	serviceItems.Gerrit, err = gerrit.Make(
+	gerritService, err := gerrit.Make(
 		sch,
 		restConf,
 		gerrit.Config{
 			Namespace:            appConf.Namespace,
 			GerritAPIUrlTemplate: appConf.GerritAPIUrlTemplate,
 			RootGerritName:       appConf.RootGerritName,
+			ClientPolicy:         appConf.ClientPolicy(),
 		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create gerrit service, %w", err)
 	}
 
+	serviceItems.Gerrit = gerritService
+
 	serviceItems.GitServer, err = gitserver.New(sch, restConf, appConf.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gitServer service: %w", err)
 	}
 
+	gitServer, err := serviceItems.GitServer.Get(appConf.GitServerName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get git server %s, %w", appConf.GitServerName, err)
+	}
+
+	serviceItems.GitProvider, err = gitprovider.Make(gitServer, gitprovider.Config{
+		GerritClient: gerritService.GoGerritClient(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to init git provider, %w", err)
+	}
+
 	serviceItems.Keycloak, err = keycloak.Make(sch, restConf, appConf.UsersNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create keycloak service, %w", err)
@@ -268,38 +378,55 @@ func initServices(
 
 	serviceItems.Cache = cache.New(time.Hour, time.Minute)
 
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-
-	go exitWait(sigs, serviceItems.Cache)
-
 	gob.Register([]registry.CachedFile{})
 	if err := serviceItems.Cache.LoadFile(cachePath); err != nil {
 		logger.Warn("unable to load cache")
 	}
 
+	lc.OnShutdown("cache", func(context.Context) error {
+		return saveCache(serviceItems.Cache)
+	})
+
 	serviceItems.PermService = permissions.Make(serviceItems.Codebase, serviceItems.K8S)
+	serviceItems.Roles = roles.Make(serviceItems.K8S, appConf.RolesConfigMap, appConf.Namespace)
+
+	runtimeConfig, err := appConf.RuntimeConfig(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare runtime config, %w", err)
+	}
+	serviceItems.Runtime = registry.MakeRuntime(runtimeConfig)
 
 	return &serviceItems, nil
 }
 
+func sessionBackend(appConf *config.Settings, k8sService k8s.ServiceInterface) session.Backend {
+	if appConf.SessionStore == config.SessionStoreMemory {
+		return session.MakeMemoryBackend()
+	}
+
+	return session.MakeSecretBackend(k8sService, appConf.Namespace)
+}
+
 func initControllers(
 	sch *runtime.Scheme,
 	namespace string,
 	logger *zap.Logger,
 	cnf *config.Settings,
 	services *config.Services,
+	lc *lifecycle.Manager,
 ) error {
 	if cnf.Mock != "" {
 		return nil
 	}
 
 	cfg := ctrl.GetConfigOrDie()
+	cfg.Wrap(tracing.Transport)
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		Scheme:             sch,
-		Namespace:          namespace,
-		MetricsBindAddress: "0",
+		Scheme:                  sch,
+		Namespace:               namespace,
+		MetricsBindAddress:      "0",
+		GracefulShutdownTimeout: &cnf.ShutdownTimeout,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to ini manager, %w", err)
@@ -307,25 +434,69 @@ func initControllers(
 
 	l := logger.Sugar()
 
-	if err := codebaseController.Make(mgr, l, cnf, services.Cache, services.Gerrit, services.Codebase); err != nil {
+	// manager cache is shared with services, so codebases and merge requests are watched once
+	for _, svc := range []any{services.Codebase, services.Gerrit} {
+		if cu, ok := svc.(cacheUser); ok {
+			if err := cu.UseCache(context.Background(), mgr.GetCache()); err != nil {
+				return fmt.Errorf("unable to switch service to manager cache, %w", err)
+			}
+		}
+	}
+
+	if err := codebaseController.Make(mgr, l, cnf, services.Cache, services.Gerrit, services.Codebase,
+		services.Runtime); err != nil {
 		return fmt.Errorf("unable to init codebase controller, %w", err)
 	}
 
-	if err := mergeRequestController.Make(mgr, l, cnf, services.Gerrit,
-		services.Codebase, services.GitServer, services.Jenkins, services.Cache); err != nil {
+	if err := mergeRequestController.Make(mgr, l, cnf, services.Gerrit, services.GitProvider,
+		services.Codebase, services.GitServer, services.Jenkins, services.Cache, services.Runtime); err != nil {
 		return fmt.Errorf("unable to init merge request controller, %w", err)
 	}
 
-	go func() {
-		if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-			logger.Sugar().Error(err.Error(), "unable to start manager")
-		}
-	}()
+	if err := registryDeletionController.Make(mgr, l, cnf, services.Codebase,
+		registry.MakeCleaner(services.Vault, services.Keycloak, services.PermService, services.Cache,
+			cnf.RegistryConfig())); err != nil {
+		return fmt.Errorf("unable to init registry deletion controller, %w", err)
+	}
+
+	if err := adminExpiryController.Make(mgr, l, cnf,
+		registry.MakeAdmins(services.Keycloak, cnf.UsersRealm, cnf.UsersNamespace)); err != nil {
+		return fmt.Errorf("unable to init admin expiry controller, %w", err)
+	}
+
+	if err := permissionsInvalidationController.Make(mgr, l, services.PermService); err != nil {
+		return fmt.Errorf("unable to init permissions invalidation controller, %w", err)
+	}
+
+	if err := runtimeConfigController.Make(mgr, l, cnf, services.Runtime); err != nil {
+		return fmt.Errorf("unable to init runtime config controller, %w", err)
+	}
+
+	// manager waits for current reconciles and stops its cache when its context is canceled
+	ctx, stop := context.WithCancel(context.Background())
+	lc.Run("controllers", func() error {
+		// cache sync wait below is released if manager fails to start
+		defer stop()
+		return mgr.Start(ctx)
+	}, func(context.Context) error {
+		stop()
+		return nil
+	})
+
+	// services read from manager cache, so requests are served only after its initial lists are synced
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		return errors.New("unable to sync manager cache")
+	}
 
 	return nil
 }
 
-func initApps(logger *zap.Logger, cnf *config.Settings, r *gin.Engine, buildTime time.Time) error {
+// cacheUser is a service that can read from shared informer cache of controllers manager
+type cacheUser interface {
+	UseCache(ctx context.Context, informerCache ctrlCache.Cache) error
+}
+
+func initApps(logger *zap.Logger, cnf *config.Settings, r *gin.Engine, buildTime time.Time, lc *lifecycle.Manager) error {
 	restConf, err := initKubeConfig()
 	if err != nil {
 		return fmt.Errorf("unable to init kube config, %w", err)
@@ -351,6 +522,7 @@ func initApps(logger *zap.Logger, cnf *config.Settings, r *gin.Engine, buildTime
 	favicon := string(logoFavicon)
 	logoMainSvg := template.HTML(decodedBytes)
 	appRouter := router.Make(r, logger, buildTime, appName, logoMainSvg, favicon)
+	r.Use(appRouter.LoggingMiddleware)
 
 	sch := runtime.NewScheme()
 
@@ -358,12 +530,19 @@ func initApps(logger *zap.Logger, cnf *config.Settings, r *gin.Engine, buildTime
 		return fmt.Errorf("unable to add core api to scheme, %w", err)
 	}
 
-	serviceItems, err := initServices(sch, restConf, cnf, logger)
+	if err := rbacv1.AddToScheme(sch); err != nil {
+		return fmt.Errorf("unable to add rbac api to scheme, %w", err)
+	}
+
+	serviceItems, err := initServices(sch, restConf, cnf, logger, lc)
 	if err != nil {
 		return fmt.Errorf("unable to init services, %w", err)
 	}
 
-	if err := initControllers(sch, cnf.Namespace, logger, cnf, serviceItems); err != nil {
+	r.Use(sessions.Sessions(sessionCookieName, serviceItems.Sessions))
+	r.Use(appRouter.SecurityMiddleware([]byte(cnf.SessionSecret), cnf.SessionConfig().Secure))
+
+	if err := initControllers(sch, cnf.Namespace, logger, cnf, serviceItems, lc); err != nil {
 		return fmt.Errorf("unable to init controllers, %w", err)
 	}
 
@@ -382,12 +561,7 @@ func initApps(logger *zap.Logger, cnf *config.Settings, r *gin.Engine, buildTime
 		return fmt.Errorf("unable to make dashboard app, %w", err)
 	}
 
-	registryConfig, err := cnf.RegistryConfig()
-	if err != nil {
-		return fmt.Errorf("failed to parse previous version: %w", err)
-	}
-
-	if _, err := registry.Make(appRouter, serviceItems.RegistryServices(), registryConfig); err != nil {
+	if _, err := registry.Make(appRouter, serviceItems.RegistryServices(), cnf.RegistryConfig()); err != nil {
 		return fmt.Errorf("unable to make registry app, %w", err)
 	}
 
@@ -409,6 +583,8 @@ func initKubeConfig() (*rest.Config, error) {
 		return nil, fmt.Errorf("unable to get k8s client config, %w", err)
 	}
 
+	restConfig.Wrap(tracing.Transport)
+
 	return restConfig, nil
 }
 
@@ -464,6 +640,7 @@ func registerOAuthInGin(oAuth dashboard.OAuth, r *gin.Engine) {
 	gob.Register(&oauth2.Token{})
 	r.Use(oauth.MakeGinMiddleware(oAuth, router.AuthTokenSessionKey, router.AuthTokenValidSessionKey, "/admin/"))
 	r.Use(router.UserDataMiddleware)
+	r.Use(roles.Middleware)
 }
 
 func majorVersion(word ...string) string {
@@ -63,6 +63,29 @@ func (_m *OAuth) GetTokenClient(ctx context.Context, code string) (*oauth2.Token
 	return r0, r1, r2
 }
 
+// RefreshToken provides a mock function with given fields: ctx, token
+func (_m *OAuth) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *oauth2.Token
+	if rf, ok := ret.Get(0).(func(context.Context, *oauth2.Token) *oauth2.Token); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oauth2.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *oauth2.Token) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewOAuth interface {
 	mock.TestingT
 	Cleanup(func())
@@ -17,20 +17,6 @@ type ServiceInterface struct {
 	mock.Mock
 }
 
-// ApproveAndSubmitChange provides a mock function with given fields: changeID, username, email
-func (_m *ServiceInterface) ApproveAndSubmitChange(changeID string, username string, email string) error {
-	ret := _m.Called(changeID, username, email)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(changeID, username, email)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // CreateMergeRequest provides a mock function with given fields: ctx, mr
 func (_m *ServiceInterface) CreateMergeRequest(ctx context.Context, mr *gerrit.MergeRequest) error {
 	ret := _m.Called(ctx, mr)
@@ -73,23 +59,25 @@ func (_m *ServiceInterface) CreateProject(ctx context.Context, name string) erro
 	return r0
 }
 
-// GetFileFromBranch provides a mock function with given fields: projectName, branch, fileLocation
-func (_m *ServiceInterface) GetFileFromBranch(projectName string, branch string, fileLocation string) (string, error) {
-	ret := _m.Called(projectName, branch, fileLocation)
+// FindMergeRequests provides a mock function with given fields: ctx, filter
+func (_m *ServiceInterface) FindMergeRequests(ctx context.Context, filter gerrit.MergeRequestFilter) ([]gerrit.GerritMergeRequest, error) {
+	ret := _m.Called(ctx, filter)
 
-	var r0 string
+	var r0 []gerrit.GerritMergeRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
-		return rf(projectName, branch, fileLocation)
+	if rf, ok := ret.Get(0).(func(context.Context, gerrit.MergeRequestFilter) ([]gerrit.GerritMergeRequest, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = rf(projectName, branch, fileLocation)
+	if rf, ok := ret.Get(0).(func(context.Context, gerrit.MergeRequestFilter) []gerrit.GerritMergeRequest); ok {
+		r0 = rf(ctx, filter)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.GerritMergeRequest)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(projectName, branch, fileLocation)
+	if rf, ok := ret.Get(1).(func(context.Context, gerrit.MergeRequestFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -355,22 +343,6 @@ func (_m *ServiceInterface) GetProjects(ctx context.Context) ([]gerrit.GerritPro
 	return r0, r1
 }
 
-// GoGerritClient provides a mock function with given fields:
-func (_m *ServiceInterface) GoGerritClient() *go_gerrit.Client {
-	ret := _m.Called()
-
-	var r0 *go_gerrit.Client
-	if rf, ok := ret.Get(0).(func() *go_gerrit.Client); ok {
-		r0 = rf()
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*go_gerrit.Client)
-		}
-	}
-
-	return r0
-}
-
 // UpdateMergeRequestStatus provides a mock function with given fields: ctx, mr
 func (_m *ServiceInterface) UpdateMergeRequestStatus(ctx context.Context, mr *gerrit.GerritMergeRequest) error {
 	ret := _m.Called(ctx, mr)
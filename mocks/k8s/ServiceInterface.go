@@ -6,6 +6,12 @@ import (
 	context "context"
 	k8s "ddm-admin-console/service/k8s"
 
+	appsv1 "k8s.io/api/apps/v1"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
 	mock "github.com/stretchr/testify/mock"
 
 	v1 "k8s.io/api/core/v1"
@@ -16,6 +22,48 @@ type ServiceInterface struct {
 	mock.Mock
 }
 
+// ApplyRole provides a mock function with given fields: ctx, role
+func (_m *ServiceInterface) ApplyRole(ctx context.Context, role *rbacv1.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rbacv1.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplyRoleBinding provides a mock function with given fields: ctx, binding
+func (_m *ServiceInterface) ApplyRoleBinding(ctx context.Context, binding *rbacv1.RoleBinding) error {
+	ret := _m.Called(ctx, binding)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rbacv1.RoleBinding) error); ok {
+		r0 = rf(ctx, binding)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplySecret provides a mock function with given fields: ctx, secret
+func (_m *ServiceInterface) ApplySecret(ctx context.Context, secret *v1.Secret) error {
+	ret := _m.Called(ctx, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Secret) error); ok {
+		r0 = rf(ctx, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanI provides a mock function with given fields: group, resource, verb, name
 func (_m *ServiceInterface) CanI(group string, resource string, verb string, name string) (bool, error) {
 	ret := _m.Called(group, resource, verb, name)
@@ -40,6 +88,74 @@ func (_m *ServiceInterface) CanI(group string, resource string, verb string, nam
 	return r0, r1
 }
 
+// DeleteRole provides a mock function with given fields: ctx, name
+func (_m *ServiceInterface) DeleteRole(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoleBinding provides a mock function with given fields: ctx, name
+func (_m *ServiceInterface) DeleteRoleBinding(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSecret provides a mock function with given fields: ctx, name
+func (_m *ServiceInterface) DeleteSecret(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClusterRole provides a mock function with given fields: ctx, name
+func (_m *ServiceInterface) GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *rbacv1.ClusterRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*rbacv1.ClusterRole, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *rbacv1.ClusterRole); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rbacv1.ClusterRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigMap provides a mock function with given fields: ctx, name, namespace
 func (_m *ServiceInterface) GetConfigMap(ctx context.Context, name string, namespace string) (*v1.ConfigMap, error) {
 	ret := _m.Called(ctx, name, namespace)
@@ -71,6 +187,162 @@ func (_m *ServiceInterface) CreateConfigMap(ctx context.Context, cm *v1.ConfigMa
 	return nil
 }
 
+// GetDeployments provides a mock function with given fields: ctx, namespace
+func (_m *ServiceInterface) GetDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []appsv1.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]appsv1.Deployment, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []appsv1.Deployment); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]appsv1.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPodMetrics provides a mock function with given fields: ctx, namespace
+func (_m *ServiceInterface) GetPodMetrics(ctx context.Context, namespace string) ([]k8s.PodMetrics, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []k8s.PodMetrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]k8s.PodMetrics, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []k8s.PodMetrics); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]k8s.PodMetrics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPods provides a mock function with given fields: ctx, namespace
+func (_m *ServiceInterface) GetPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []v1.Pod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.Pod, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.Pod); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Pod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResourceQuotas provides a mock function with given fields: ctx, namespace
+func (_m *ServiceInterface) GetResourceQuotas(ctx context.Context, namespace string) ([]v1.ResourceQuota, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []v1.ResourceQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.ResourceQuota, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.ResourceQuota); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.ResourceQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleBindings provides a mock function with given fields: ctx
+func (_m *ServiceInterface) GetRoleBindings(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []rbacv1.RoleBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]rbacv1.RoleBinding, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []rbacv1.RoleBinding); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rbacv1.RoleBinding)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *ServiceInterface) GetRoles(ctx context.Context) ([]rbacv1.Role, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []rbacv1.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]rbacv1.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []rbacv1.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rbacv1.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSecret provides a mock function with given fields: name
 func (_m *ServiceInterface) GetSecret(name string) (*v1.Secret, error) {
 	ret := _m.Called(name)
@@ -173,6 +445,58 @@ func (_m *ServiceInterface) GetSecretKeys(ctx context.Context, namespace string,
 	return r0, r1
 }
 
+// GetSecrets provides a mock function with given fields: ctx, labelSelector
+func (_m *ServiceInterface) GetSecrets(ctx context.Context, labelSelector string) ([]v1.Secret, error) {
+	ret := _m.Called(ctx, labelSelector)
+
+	var r0 []v1.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.Secret, error)); ok {
+		return rf(ctx, labelSelector)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.Secret); ok {
+		r0 = rf(ctx, labelSelector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, labelSelector)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatefulSets provides a mock function with given fields: ctx, namespace
+func (_m *ServiceInterface) GetStatefulSets(ctx context.Context, namespace string) ([]appsv1.StatefulSet, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []appsv1.StatefulSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]appsv1.StatefulSet, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []appsv1.StatefulSet); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]appsv1.StatefulSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecreateSecret provides a mock function with given fields: secretName, data
 func (_m *ServiceInterface) RecreateSecret(secretName string, data map[string][]byte) error {
 	ret := _m.Called(secretName, data)
@@ -187,6 +511,91 @@ func (_m *ServiceInterface) RecreateSecret(secretName string, data map[string][]
 	return r0
 }
 
+// ResourceRules provides a mock function with given fields: ctx
+func (_m *ServiceInterface) ResourceRules(ctx context.Context) ([]authorizationv1.ResourceRule, bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []authorizationv1.ResourceRule
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]authorizationv1.ResourceRule, bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []authorizationv1.ResourceRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]authorizationv1.ResourceRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReviewSelfAccess provides a mock function with given fields: ctx, group, resource, verb, name
+func (_m *ServiceInterface) ReviewSelfAccess(ctx context.Context, group string, resource string, verb string, name string) (*k8s.AccessReview, error) {
+	ret := _m.Called(ctx, group, resource, verb, name)
+
+	var r0 *k8s.AccessReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*k8s.AccessReview, error)); ok {
+		return rf(ctx, group, resource, verb, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *k8s.AccessReview); ok {
+		r0 = rf(ctx, group, resource, verb, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*k8s.AccessReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, group, resource, verb, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewSubjectAccess provides a mock function with given fields: ctx, user, groups, group, resource, verb, name
+func (_m *ServiceInterface) ReviewSubjectAccess(ctx context.Context, user string, groups []string, group string, resource string, verb string, name string) (*k8s.AccessReview, error) {
+	ret := _m.Called(ctx, user, groups, group, resource, verb, name)
+
+	var r0 *k8s.AccessReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, string, string, string) (*k8s.AccessReview, error)); ok {
+		return rf(ctx, user, groups, group, resource, verb, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, string, string, string) *k8s.AccessReview); ok {
+		r0 = rf(ctx, user, groups, group, resource, verb, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*k8s.AccessReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string, string, string, string) error); ok {
+		r1 = rf(ctx, user, groups, group, resource, verb, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ServiceForContext provides a mock function with given fields: ctx
 func (_m *ServiceInterface) ServiceForContext(ctx context.Context) (k8s.ServiceInterface, error) {
 	ret := _m.Called(ctx)
@@ -68,6 +68,32 @@ func (_m *ServiceInterface) GetUsers(ctx context.Context) ([]keycloak.KeycloakRe
 	return r0, r1
 }
 
+// GetUsersByNamespace provides a mock function with given fields: ctx, namespace, realmName
+func (_m *ServiceInterface) GetUsersByNamespace(ctx context.Context, namespace string, realmName string) ([]keycloak.KeycloakRealmUser, error) {
+	ret := _m.Called(ctx, namespace, realmName)
+
+	var r0 []keycloak.KeycloakRealmUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]keycloak.KeycloakRealmUser, error)); ok {
+		return rf(ctx, namespace, realmName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []keycloak.KeycloakRealmUser); ok {
+		r0 = rf(ctx, namespace, realmName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]keycloak.KeycloakRealmUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, realmName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsersByRealm provides a mock function with given fields: ctx, realmName
 func (_m *ServiceInterface) GetUsersByRealm(ctx context.Context, realmName string) ([]keycloak.KeycloakRealmUser, error) {
 	ret := _m.Called(ctx, realmName)
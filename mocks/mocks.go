@@ -2,14 +2,15 @@ package mocks
 
 import (
 	"errors"
-	"net/url"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/oauth2"
 	"gopkg.in/yaml.v2"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"ddm-admin-console/app/cluster"
@@ -19,16 +20,22 @@ import (
 	mockDashboard "ddm-admin-console/mocks/dashboard"
 	mockEdpComponent "ddm-admin-console/mocks/edp_component"
 	mockGerrit "ddm-admin-console/mocks/gerrit"
+	mockGitProvider "ddm-admin-console/mocks/gitprovider"
 	mockJenkins "ddm-admin-console/mocks/jenkins"
 	mockK8S "ddm-admin-console/mocks/k8s"
 	mockKeycloak "ddm-admin-console/mocks/keycloak"
 	mockOpenshift "ddm-admin-console/mocks/openshift"
 	mockPermissions "ddm-admin-console/mocks/permissions"
+	mockRoles "ddm-admin-console/mocks/roles"
 	mockVault "ddm-admin-console/mocks/vault"
 	"ddm-admin-console/service/codebase"
 	edpcomponent "ddm-admin-console/service/edp_component"
 	"ddm-admin-console/service/gerrit"
+	"ddm-admin-console/service/gitprovider"
 	"ddm-admin-console/service/openshift"
+	"ddm-admin-console/service/permissions"
+	"ddm-admin-console/service/roles"
+	"ddm-admin-console/service/session"
 )
 
 func InitServices(cnf *config.Settings) *config.Services {
@@ -49,7 +56,17 @@ func InitServices(cnf *config.Settings) *config.Services {
 
 	pms := mockPermissions.ServiceInterface{}
 	pms.On("DeleteTokenContext", mock.Anything).Return(nil)
-	pms.On("LoadUserRegistries", mock.Anything).Return(nil)
+	pms.On("HasRegistryAccess", mock.Anything).Return(true, nil)
+	pms.On("ExplainRegistries", mock.Anything, mock.Anything).Return(
+		func(_ *gin.Context, names []string) map[string]permissions.RegistryDecision {
+			decisions := make(map[string]permissions.RegistryDecision, len(names))
+			for _, n := range names {
+				decisions[n] = permissions.RegistryDecision{Source: permissions.SourceRulesReview,
+					RegistryPermission: permissions.RegistryPermission{CanGet: true, CanUpdate: true, CanDelete: true}}
+			}
+
+			return decisions
+		}, nil)
 	codebaseVersion, _ := version.NewVersion("1.9.3.34")
 	pms.On("FilterCodebases", mock.Anything, mock.Anything, mock.Anything).Return([]codebase.WithPermissions{
 		{
@@ -71,16 +88,24 @@ func InitServices(cnf *config.Settings) *config.Services {
 		},
 	}, nil)
 
+	rolesService := mockRoles.ServiceInterface{}
+	rolesService.On("UserRoles", mock.Anything, mock.Anything).Return([]string{roles.RolePlatformAdmin}, nil)
+
 	svc := config.Services{
 		Codebase:     initCodebaseService(cnf),
 		Vault:        initVault(),
 		K8S:          initK8SService(cnf),
 		Jenkins:      initJenkinsService(),
 		Gerrit:       initMockGerrit(cnf),
+		GitProvider:  initMockGitProvider(cnf),
 		EDPComponent: initEDPComponent(),
 		Keycloak:     &mockKeycloak.ServiceInterface{},
 		OpenShift:    &openShift,
 		PermService:  &pms,
+		Roles:        &rolesService,
+		Runtime:      registry.MakeRuntime(&registry.RuntimeConfig{VersionFilter: &registry.VersionFilter{}}),
+		Sessions: session.Make(session.MakeMemoryBackend(), []byte(cnf.SessionSecret),
+			cnf.SessionConfig()),
 	}
 
 	return &svc
@@ -187,28 +212,18 @@ func initK8SService(cnf *config.Settings) *mockK8S.ServiceInterface {
 	k8sService := mockK8S.ServiceInterface{}
 	k8sService.On("ServiceForContext", mock.Anything).Return(&k8sService, nil)
 	k8sService.On("CanI", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	k8sService.On("ResourceRules", mock.Anything).Return([]authorizationv1.ResourceRule{
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+	}, false, nil)
 
 	return &k8sService
 }
 
-func initMockGerrit(cnf *config.Settings) *mockGerrit.ServiceInterface {
-	grService := mockGerrit.ServiceInterface{}
-	grService.On("GetMergeRequests", mock.Anything).Return([]gerrit.GerritMergeRequest{}, nil)
-	grService.On("GetProjects", mock.Anything).Return([]gerrit.GerritProject{
-		{
-			ObjectMeta: metav1.ObjectMeta{Name: "mock-project"},
-			Status: gerrit.GerritProjectStatus{
-				Branches: []string{"mock-branch"},
-			},
-		},
-		{
-			ObjectMeta: metav1.ObjectMeta{Name: "registry-tenant-template-tpl1"},
-			Status: gerrit.GerritProjectStatus{
-				Branches: []string{"refs/heads/1.0"},
-			},
-			Spec: gerrit.GerritProjectSpec{Name: "registry-tenant-template-tpl1"},
-		},
-	}, nil)
+func initMockGitProvider(cnf *config.Settings) *mockGitProvider.Provider {
+	gpService := mockGitProvider.Provider{}
+	gpService.On("Name").Return(gitprovider.Gerrit)
+	gpService.On("GetFile", mock.Anything, mock.Anything, mock.Anything, registry.ValuesSchemaLocation).
+		Return("", gitprovider.ErrNotFound)
 
 	mockClusterValuesFromRegistry := registry.ClusterValues{Keycloak: registry.ClusterKeycloak{CustomHosts: []registry.CustomHost{
 		{Host: "foo.bar.com"},
@@ -219,8 +234,8 @@ func initMockGerrit(cnf *config.Settings) *mockGerrit.ServiceInterface {
 		panic(err)
 	}
 
-	grService.On("GetFileFromBranch", cnf.ClusterCodebaseName, "master",
-		url.PathEscape(registry.ValuesLocation)).Return(string(clusterValuesBts), nil)
+	gpService.On("GetFile", mock.Anything, cnf.ClusterCodebaseName, "master", registry.ValuesLocation).
+		Return(string(clusterValuesBts), nil)
 
 	mockRegistryValues := registry.Values{
 		Global: registry.Global{
@@ -272,9 +287,33 @@ func initMockGerrit(cnf *config.Settings) *mockGerrit.ServiceInterface {
 		panic(err)
 	}
 
-	grService.On("GetFileFromBranch", "mock", "master", url.PathEscape(registry.ValuesLocation)).Return(string(registryValuesBts), nil)
-	grService.On("GetFileFromBranch", "registry-tenant-template-tpl1", "1.0",
-		url.PathEscape(registry.ValuesLocation)).Return(string(registryValuesBts), nil)
+	gpService.On("GetFile", mock.Anything, "mock", "master", registry.ValuesLocation).Return(string(registryValuesBts), nil)
+	gpService.On("GetFile", mock.Anything, "registry-tenant-template-tpl1", "1.0", registry.ValuesLocation).
+		Return(string(registryValuesBts), nil)
+
+	return &gpService
+}
+
+func initMockGerrit(cnf *config.Settings) *mockGerrit.ServiceInterface {
+	grService := mockGerrit.ServiceInterface{}
+	grService.On("GetMergeRequests", mock.Anything).Return([]gerrit.GerritMergeRequest{}, nil)
+	grService.On("FindMergeRequests", mock.Anything, mock.Anything).Return([]gerrit.GerritMergeRequest{}, nil)
+	grService.On("GetProjects", mock.Anything).Return([]gerrit.GerritProject{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "mock-project"},
+			Status: gerrit.GerritProjectStatus{
+				Branches: []string{"mock-branch"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-tenant-template-tpl1"},
+			Status: gerrit.GerritProjectStatus{
+				Branches: []string{"refs/heads/1.0"},
+			},
+			Spec: gerrit.GerritProjectSpec{Name: "registry-tenant-template-tpl1"},
+		},
+	}, nil)
+
 	grService.On("GetMergeRequestByProject", mock.Anything, cnf.ClusterCodebaseName).Return([]gerrit.GerritMergeRequest{
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "mock-mr", Labels: map[string]string{}},
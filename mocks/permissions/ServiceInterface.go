@@ -5,13 +5,13 @@ package mocks
 import (
 	codebase "ddm-admin-console/service/codebase"
 
+	permissions "ddm-admin-console/service/permissions"
+
 	gin "github.com/gin-gonic/gin"
 
 	k8s "ddm-admin-console/service/k8s"
 
 	mock "github.com/stretchr/testify/mock"
-
-	permissions "ddm-admin-console/service/permissions"
 )
 
 // ServiceInterface is an autogenerated mock type for the ServiceInterface type
@@ -43,6 +43,32 @@ func (_m *ServiceInterface) DeleteTokenContext(ctx *gin.Context) error {
 	return r0
 }
 
+// ExplainRegistries provides a mock function with given fields: ctx, names
+func (_m *ServiceInterface) ExplainRegistries(ctx *gin.Context, names []string) (map[string]permissions.RegistryDecision, error) {
+	ret := _m.Called(ctx, names)
+
+	var r0 map[string]permissions.RegistryDecision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gin.Context, []string) (map[string]permissions.RegistryDecision, error)); ok {
+		return rf(ctx, names)
+	}
+	if rf, ok := ret.Get(0).(func(*gin.Context, []string) map[string]permissions.RegistryDecision); ok {
+		r0 = rf(ctx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]permissions.RegistryDecision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gin.Context, []string) error); ok {
+		r1 = rf(ctx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilterCodebases provides a mock function with given fields: ginContext, cbs, k8sService
 func (_m *ServiceInterface) FilterCodebases(ginContext *gin.Context, cbs []codebase.Codebase, k8sService k8s.ServiceInterface) ([]codebase.WithPermissions, error) {
 	ret := _m.Called(ginContext, cbs, k8sService)
@@ -69,25 +95,23 @@ func (_m *ServiceInterface) FilterCodebases(ginContext *gin.Context, cbs []codeb
 	return r0, r1
 }
 
-// GetPermission provides a mock function with given fields: token, registryName
-func (_m *ServiceInterface) GetPermission(token string, registryName string) (*permissions.RegistryPermission, error) {
-	ret := _m.Called(token, registryName)
+// HasRegistryAccess provides a mock function with given fields: ctx
+func (_m *ServiceInterface) HasRegistryAccess(ctx *gin.Context) (bool, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *permissions.RegistryPermission
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*permissions.RegistryPermission, error)); ok {
-		return rf(token, registryName)
+	if rf, ok := ret.Get(0).(func(*gin.Context) (bool, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *permissions.RegistryPermission); ok {
-		r0 = rf(token, registryName)
+	if rf, ok := ret.Get(0).(func(*gin.Context) bool); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*permissions.RegistryPermission)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(token, registryName)
+	if rf, ok := ret.Get(1).(func(*gin.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -95,23 +119,9 @@ func (_m *ServiceInterface) GetPermission(token string, registryName string) (*p
 	return r0, r1
 }
 
-// LoadUserRegistries provides a mock function with given fields: ctx
-func (_m *ServiceInterface) LoadUserRegistries(ctx *gin.Context) error {
-	ret := _m.Called(ctx)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gin.Context) error); ok {
-		r0 = rf(ctx)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// SetPermission provides a mock function with given fields: token, registryName, permission
-func (_m *ServiceInterface) SetPermission(token string, registryName string, permission permissions.RegistryPermission) {
-	_m.Called(token, registryName, permission)
+// Invalidate provides a mock function with given fields:
+func (_m *ServiceInterface) Invalidate() {
+	_m.Called()
 }
 
 type mockConstructorTestingTNewServiceInterface interface {
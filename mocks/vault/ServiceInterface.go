@@ -12,6 +12,20 @@ type ServiceInterface struct {
 	mock.Mock
 }
 
+// DeleteAll provides a mock function with given fields: path
+func (_m *ServiceInterface) DeleteAll(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPropertyFromVault provides a mock function with given fields: path, property
 func (_m *ServiceInterface) GetPropertyFromVault(path string, property string) string {
 	ret := _m.Called(path, property)
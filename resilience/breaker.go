@@ -0,0 +1,135 @@
+package resilience
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// OpenError is returned instead of calling backend whose breaker is open
+type OpenError struct {
+	Service string
+	Until   time.Time
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s is unavailable, calls are suspended until %s", e.Service, e.Until.Format(time.RFC3339))
+}
+
+// Breaker opens after threshold of consecutive failures and rejects calls during cooldown,
+// after cooldown single trial call decides if breaker closes or opens again
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = make(map[string]*Breaker)
+)
+
+// GetBreaker returns breaker shared by all clients of service, threshold and cooldown of first call are used
+func GetBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	if b, ok := breakers[name]; ok {
+		return b
+	}
+
+	b := &Breaker{name: name, threshold: threshold, cooldown: cooldown, now: time.Now}
+	breakers[name] = b
+
+	return b
+}
+
+// OpenBreakers returns sorted names of services whose breakers are open
+func OpenBreakers() []string {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	var names []string
+
+	for name, b := range breakers {
+		if b.Open() {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Allow returns OpenError if call must not be made
+func (b *Breaker) Allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return &OpenError{Service: b.name, Until: b.openedAt.Add(b.cooldown)}
+		}
+
+		b.state = stateHalfOpen
+
+		return nil
+	case stateHalfOpen:
+		// trial call is in progress
+		return &OpenError{Service: b.name, Until: b.now().Add(b.cooldown)}
+	default:
+		return nil
+	}
+}
+
+// Record reports result of allowed call
+func (b *Breaker) Record(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = stateClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.state = stateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Open reports if calls are currently rejected
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state != stateClosed
+}
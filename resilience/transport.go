@@ -0,0 +1,126 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Policy of backend client, zero values disable corresponding feature
+type Policy struct {
+	// Timeout limits each attempt of call including reading of response body
+	Timeout time.Duration
+	// Retries is count of additional attempts of idempotent calls failed with network error or 5xx status
+	Retries int
+	// Backoff is delay before first retry, it doubles with every next retry
+	Backoff time.Duration
+	// BreakerThreshold is count of consecutive failed calls which opens breaker
+	BreakerThreshold int
+	// BreakerCooldown is time breaker stays open before trial call
+	BreakerCooldown time.Duration
+}
+
+type transport struct {
+	next    http.RoundTripper
+	policy  Policy
+	breaker *Breaker
+}
+
+// Transport applies policy to calls of service made through rt, clients of the same service share breaker
+func Transport(service string, policy Policy, rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &transport{
+		next:    rt,
+		policy:  policy,
+		breaker: GetBreaker(service, policy.BreakerThreshold, policy.BreakerCooldown),
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	attempts := 1
+	if idempotent(req) {
+		attempts += t.policy.Retries
+	}
+
+	backoff := t.policy.Backoff
+
+	for attempt := 1; ; attempt++ {
+		rsp, err := t.attempt(req)
+		if !failed(rsp, err) || attempt >= attempts || req.Context().Err() != nil {
+			t.breaker.Record(!failed(rsp, err))
+			return rsp, err
+		}
+
+		if rsp != nil {
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			t.breaker.Record(false)
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (t *transport) attempt(req *http.Request) (*http.Response, error) {
+	if t.policy.Timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.Timeout)
+
+	rsp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// timeout covers reading of body, so context is released only when body is closed
+	rsp.Body = &cancelBody{ReadCloser: rsp.Body, cancel: cancel}
+
+	return rsp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// failed reports calls which indicate backend problem, client errors are successful calls for breaker
+func failed(rsp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	return rsp.StatusCode >= http.StatusInternalServerError
+}
+
+// idempotent requests have no body to replay and can be repeated safely
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody
+	default:
+		return false
+	}
+}
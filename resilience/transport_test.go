@@ -0,0 +1,109 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_Retry(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cl := http.Client{Transport: Transport("retry-test", Policy{Retries: 2, Backoff: time.Millisecond}, nil)}
+
+	rsp, err := cl.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, rsp.Body.Close())
+	require.Equal(t, http.StatusOK, rsp.StatusCode)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	rsp, err = cl.Post(srv.URL, "text/plain", strings.NewReader("body"))
+	require.NoError(t, err)
+	require.NoError(t, rsp.Body.Close())
+	require.Equal(t, http.StatusBadGateway, rsp.StatusCode, "non idempotent call is not retried")
+	require.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestTransport_Timeout(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	cl := http.Client{Transport: Transport("timeout-test", Policy{Timeout: 10 * time.Millisecond}, nil)}
+
+	_, err := cl.Get(srv.URL)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	fail := int32(1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	b := GetBreaker("breaker-test", 2, time.Minute)
+	b.now = func() time.Time { return now }
+	cl := http.Client{Transport: Transport("breaker-test", Policy{BreakerThreshold: 2, BreakerCooldown: time.Minute}, nil)}
+
+	get := func() (*http.Response, error) {
+		rsp, err := cl.Get(srv.URL)
+		if err == nil {
+			require.NoError(t, rsp.Body.Close())
+		}
+
+		return rsp, err
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := get()
+		require.NoError(t, err)
+	}
+
+	require.Contains(t, OpenBreakers(), "breaker-test")
+
+	_, err := get()
+	openErr := new(OpenError)
+	require.True(t, errors.As(err, &openErr), "calls are rejected while breaker is open")
+	require.Equal(t, "breaker-test", openErr.Service)
+
+	atomic.StoreInt32(&fail, 0)
+	now = now.Add(2 * time.Minute)
+
+	rsp, err := get()
+	require.NoError(t, err, "trial call after cooldown")
+	require.Equal(t, http.StatusNotFound, rsp.StatusCode)
+	require.NotContains(t, OpenBreakers(), "breaker-test", "client errors close breaker")
+}
@@ -1,14 +1,17 @@
 package router
 
 import (
+	"errors"
 	"reflect"
 
 	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 )
 
 type FieldError struct {
 	tag   string
 	field string
+	param string
 }
 
 func (f FieldError) Tag() string {
@@ -40,7 +43,7 @@ func (f FieldError) Value() interface{} {
 }
 
 func (f FieldError) Param() string {
-	return ""
+	return f.param
 }
 
 func (f FieldError) Kind() reflect.Kind {
@@ -62,3 +65,17 @@ func (f FieldError) Error() string {
 func MakeFieldError(field, tag string) *FieldError {
 	return &FieldError{tag: tag, field: field}
 }
+
+func MakeFieldErrorWithParam(field, tag, param string) *FieldError {
+	return &FieldError{tag: tag, field: field, param: param}
+}
+
+// FieldErrors returns validation errors wrapped into err
+func FieldErrors(err error) validator.ValidationErrors {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return validationErrors
+	}
+
+	return nil
+}
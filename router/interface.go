@@ -7,8 +7,8 @@ import (
 )
 
 type Interface interface {
-	GET(relativePath string, handler func(ctx *gin.Context) (Response, error))
-	POST(relativePath string, handler func(ctx *gin.Context) (Response, error))
+	GET(relativePath string, handler func(ctx *gin.Context) (Response, error), opts ...RouteOption)
+	POST(relativePath string, handler func(ctx *gin.Context) (Response, error), opts ...RouteOption)
 	//ContextWithUserAccessToken(ctx *gin.Context) context.Context
 	AddView(route string, view View)
 	AddValidator(tag string, valid validator.Func) error
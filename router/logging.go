@@ -0,0 +1,103 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"ddm-admin-console/logging"
+	"ddm-admin-console/tracing"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	requestLoggerKey = "request-logger"
+	maxRequestIDLen  = 64
+)
+
+type requestLogger struct {
+	base Logger
+	ctx  *gin.Context
+}
+
+func (l requestLogger) Error(msg string, fields ...zap.Field) {
+	l.base.Error(msg, append(requestFields(l.ctx), fields...)...)
+}
+
+func (l requestLogger) Info(msg string, fields ...zap.Field) {
+	l.base.Info(msg, append(requestFields(l.ctx), fields...)...)
+}
+
+// LoggingMiddleware assigns id to request, keeps request fields in context and logs one access line per request,
+// it should be registered before other middlewares so rejected requests are logged too
+func (r *Router) LoggingMiddleware(ctx *gin.Context) {
+	start := time.Now()
+
+	requestID := ctx.GetHeader(RequestIDHeader)
+	if requestID == "" || len(requestID) > maxRequestIDLen {
+		requestID = uuid.NewString()
+	}
+
+	ctx.Header(RequestIDHeader, requestID)
+	ctx.Set(requestLoggerKey, r.requestLogger(ctx))
+	ctx.Request = ctx.Request.WithContext(logging.WithFields(ctx.Request.Context(), &logging.Fields{
+		RequestID: requestID,
+		Registry:  registryParam(ctx),
+	}))
+
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	r.requestLogger(ctx).Info("request",
+		zap.String("method", ctx.Request.Method),
+		zap.String("path", ctx.Request.URL.Path),
+		zap.Int("status", status),
+		zap.String("outcome", outcome(status)),
+		zap.Duration("latency", time.Since(start)),
+	)
+}
+
+// RequestLogger returns logger which adds request id, user, registry, route and trace ids to each entry,
+// requests not passed through LoggingMiddleware are not logged
+func RequestLogger(ctx *gin.Context) Logger {
+	if l, ok := ctx.Get(requestLoggerKey); ok {
+		return l.(Logger)
+	}
+
+	return requestLogger{base: zap.NewNop(), ctx: ctx}
+}
+
+func (r *Router) requestLogger(ctx *gin.Context) Logger {
+	return requestLogger{base: r.logger, ctx: ctx}
+}
+
+func requestFields(ctx *gin.Context) []zap.Field {
+	zapFields := append(logging.FromContext(ctx.Request.Context()).Zap(), zap.String("route", ctx.FullPath()))
+
+	return append(zapFields, tracing.ZapFields(ctx)...)
+}
+
+// registryParam returns name of registry of registry routes
+func registryParam(ctx *gin.Context) string {
+	if !strings.HasPrefix(ctx.FullPath(), "/admin/registry/") {
+		return ""
+	}
+
+	return ctx.Param("name")
+}
+
+func outcome(status int) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return "failed"
+	case status >= http.StatusBadRequest:
+		return "rejected"
+	default:
+		return "success"
+	}
+}
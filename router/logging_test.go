@@ -0,0 +1,68 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"ddm-admin-console/logging"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.InfoLevel)
+	engine := gin.New()
+	r := Make(engine, zap.New(core), time.Now(), "console", "", "")
+	engine.Use(r.LoggingMiddleware)
+	engine.Use(sessions.Sessions("test", cookie.NewStore([]byte("secret"))))
+	engine.Use(func(ctx *gin.Context) {
+		ctx.Set(UserEmailSessionKey, "jdoe@example.com")
+		logging.FromContext(ctx.Request.Context()).User = ctx.GetString(UserEmailSessionKey)
+	})
+
+	var annotations map[string]string
+	r.POST("/admin/registry/edit/:name", func(ctx *gin.Context) (Response, error) {
+		annotations = logging.FromContext(ContextWithUserAccessToken(ctx)).Annotate(nil)
+		return nil, errors.New("fatal")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/registry/edit/reg-1", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "req-1", w.Header().Get(RequestIDHeader))
+	require.Equal(t, map[string]string{
+		logging.RequestIDAnnotation: "req-1",
+		logging.UserAnnotation:      "jdoe@example.com",
+		logging.RegistryAnnotation:  "reg-1",
+	}, annotations)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	require.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+
+	access := entries[1].ContextMap()
+	require.Equal(t, "req-1", access["request_id"])
+	require.Equal(t, "jdoe@example.com", access["user"])
+	require.Equal(t, "reg-1", access["registry"])
+	require.Equal(t, "/admin/registry/edit/:name", access["route"])
+	require.Equal(t, "failed", access["outcome"])
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	require.NotEmpty(t, w.Header().Get(RequestIDHeader), "request id is generated")
+	require.Equal(t, "rejected", logs.AllUntimed()[2].ContextMap()["outcome"])
+}
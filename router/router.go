@@ -1,18 +1,24 @@
 package router
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"ddm-admin-console/resilience"
 )
 
 var ConsoleVersion = "0"
 
 type Logger interface {
 	Error(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
 }
 
 type Router struct {
@@ -22,6 +28,7 @@ type Router struct {
 	appName     string
 	logoMain    template.HTML
 	logoFavicon string
+	routes      map[string]routeOptions
 }
 
 type HTMLResponse struct {
@@ -85,14 +92,25 @@ func MakeRedirectResponse(code int, path string) Response {
 func (r *Router) makeViewResponder(handler func(ctx *gin.Context) (Response, error)) func(ctx *gin.Context) {
 	return func(ctx *gin.Context) {
 		rsp, err := handler(ctx)
+		if fieldErrors := FieldErrors(err); len(fieldErrors) > 0 {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": fieldErrorMessages(fieldErrors)})
+			return
+		}
+
+		if openErr := new(resilience.OpenError); errors.As(err, &openErr) {
+			r.requestLogger(ctx).Error(openErr.Error())
+			r.degradedResponse(ctx)
+			return
+		}
+
 		if err != nil {
-			r.logger.Error(fmt.Sprintf("%+v", err))
+			r.requestLogger(ctx).Error(fmt.Sprintf("%+v", err))
 			ctx.String(500, "%+v", err)
 			return
 		}
 
 		if rsp == nil {
-			r.logger.Error("empty view response")
+			r.requestLogger(ctx).Error("empty view response")
 			ctx.String(500, "empty view response")
 			return
 		}
@@ -120,10 +138,30 @@ func (r *Router) includeSessionVars(ctx *gin.Context, params gin.H) gin.H {
 	params["canViewRegistries"] = ctx.GetBool(CanViewRegistriesSessionKey) || ctx.GetBool(CanCreateRegistriesSessionKey)
 	params["canViewClusterManagement"] = ctx.GetBool(CanViewClusterManagementSessionKey)
 	params["canViewClusterManagement"] = ctx.GetBool(CanViewClusterManagementSessionKey)
+	params["csrfToken"] = ctx.GetString(CSRFTokenKey)
+	params["cspNonce"] = ctx.GetString(CSPNonceKey)
+	params["degradedServices"] = resilience.OpenBreakers()
 
 	return params
 }
 
+// degradedResponse replaces internal error of call to suspended backend, pages show banner with unavailable services
+func (r *Router) degradedResponse(ctx *gin.Context) {
+	if ctx.GetHeader("X-Requested-With") == "XMLHttpRequest" || strings.Contains(ctx.GetHeader("Accept"), "application/json") {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":    "service is temporarily unavailable",
+			"degraded": resilience.OpenBreakers(),
+		})
+
+		return
+	}
+
+	params := gin.H{"appName": r.appName, "consoleVersion": ConsoleVersion}
+	params = r.includeSessionVars(ctx, params)
+	params = r.includeLogos(params)
+	ctx.HTML(http.StatusServiceUnavailable, "dashboard/degraded.html", params)
+}
+
 func (r *Router) includeLogos(params gin.H) gin.H {
 	params["logoMainSVG"] = r.logoMain
 	params["logoFavicon"] = r.logoFavicon
@@ -131,14 +169,29 @@ func (r *Router) includeLogos(params gin.H) gin.H {
 	return params
 }
 
-func (r *Router) GET(relativePath string, handler func(ctx *gin.Context) (Response, error)) {
+func (r *Router) GET(relativePath string, handler func(ctx *gin.Context) (Response, error), opts ...RouteOption) {
+	r.addRouteOptions(http.MethodGet, relativePath, opts)
 	r.engine.GET(relativePath, r.makeViewResponder(handler))
 }
 
-func (r *Router) POST(relativePath string, handler func(ctx *gin.Context) (Response, error)) {
+func (r *Router) POST(relativePath string, handler func(ctx *gin.Context) (Response, error), opts ...RouteOption) {
+	r.addRouteOptions(http.MethodPost, relativePath, opts)
 	r.engine.POST(relativePath, r.makeViewResponder(handler))
 }
 
+func (r *Router) addRouteOptions(method, relativePath string, opts []RouteOption) {
+	if len(opts) == 0 {
+		return
+	}
+
+	var o routeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r.routes[routeKey(method, relativePath)] = o
+}
+
 func Make(engine *gin.Engine, logger Logger, buildTime time.Time, appName string, logoMain template.HTML, logoFavicon string) *Router {
 	return &Router{
 		engine:      engine,
@@ -147,5 +200,6 @@ func Make(engine *gin.Engine, logger Logger, buildTime time.Time, appName string
 		appName:     appName,
 		logoMain:    logoMain,
 		logoFavicon: logoFavicon,
+		routes:      make(map[string]routeOptions),
 	}
 }
@@ -0,0 +1,135 @@
+package router
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CSRFTokenKey  = "csrf-token"
+	CSPNonceKey   = "csp-nonce"
+	CSRFHeader    = "X-CSRF-Token"
+	CSRFFormField = "csrf_token"
+
+	nonceLength = 16
+	hstsMaxAge  = 365 * 24 * 60 * 60
+)
+
+// RouteOption changes security headers of single route, csrf check has no opt-out because every
+// state changing route, JSON API included, is authorized by session cookie
+type RouteOption func(o *routeOptions)
+
+type routeOptions struct {
+	skipCSP bool
+}
+
+// SkipCSP disables content security policy of route, it is meant only for JSON API routes
+// whose responses are never rendered as pages
+func SkipCSP(o *routeOptions) {
+	o.skipCSP = true
+}
+
+// SecurityMiddleware sets security headers and rejects state changing requests without csrf token of session,
+// token is an HMAC of session id, so it changes with every new session and does not need to be stored
+func (r *Router) SecurityMiddleware(secret []byte, hsts bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		opts := r.routes[routeKey(ctx.Request.Method, ctx.FullPath())]
+
+		nonce, err := randomNonce()
+		if err != nil {
+			r.logger.Error(err.Error())
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.Set(CSPNonceKey, nonce)
+		setSecurityHeaders(ctx.Writer.Header(), nonce, opts, hsts)
+
+		token := CSRFToken(secret, sessions.Default(ctx).ID())
+		ctx.Set(CSRFTokenKey, token)
+
+		if !safeMethod(ctx.Request.Method) && !validCSRFToken(ctx, token) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing or invalid csrf token"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// CSRFToken returns csrf token of session
+func CSRFToken(secret []byte, sessionID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("csrf:" + sessionID))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func validCSRFToken(ctx *gin.Context, expected string) bool {
+	token := ctx.GetHeader(CSRFHeader)
+	if token == "" {
+		token = ctx.PostForm(CSRFFormField)
+	}
+
+	return hmac.Equal([]byte(token), []byte(expected))
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func setSecurityHeaders(h http.Header, nonce string, opts routeOptions, hsts bool) {
+	if !opts.skipCSP {
+		h.Set("Content-Security-Policy", contentSecurityPolicy(nonce))
+	}
+
+	// change pages are shown in frames of registry and cluster pages
+	h.Set("X-Frame-Options", "SAMEORIGIN")
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Referrer-Policy", "same-origin")
+	h.Set("Cross-Origin-Opener-Policy", "same-origin")
+	h.Set("Permissions-Policy", "camera=(), microphone=(), geolocation=()")
+
+	if hsts {
+		h.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d", hstsMaxAge))
+	}
+}
+
+// contentSecurityPolicy allows only console own resources, inline scripts must carry nonce of request,
+// inline styles are still allowed because templates and frontend components use style attributes
+func contentSecurityPolicy(nonce string) string {
+	return strings.Join([]string{
+		"default-src 'self'",
+		fmt.Sprintf("script-src 'self' 'nonce-%s'", nonce),
+		"style-src 'self' 'unsafe-inline'",
+		"img-src 'self' data:",
+		"font-src 'self' data:",
+		"connect-src 'self'",
+		"frame-src 'self'",
+		"frame-ancestors 'self'",
+		"form-action 'self'",
+		"base-uri 'self'",
+		"object-src 'none'",
+	}, "; ")
+}
+
+func randomNonce() (string, error) {
+	b := make([]byte, nonceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate csp nonce, %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
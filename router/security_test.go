@@ -0,0 +1,75 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSecurityMiddleware(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	engine := gin.New()
+	r := Make(engine, zap.NewNop(), time.Now(), "console", "", "")
+	engine.Use(sessions.Sessions("test", cookie.NewStore(secret)))
+	engine.Use(r.SecurityMiddleware(secret, true))
+
+	ok := func(ctx *gin.Context) (Response, error) {
+		return MakeJSONResponse(http.StatusOK, gin.H{"token": ctx.GetString(CSRFTokenKey)}), nil
+	}
+	r.GET("/page", ok)
+	r.POST("/action", ok)
+	r.GET("/api", ok, SkipCSP)
+	r.POST("/api", ok, SkipCSP)
+
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := do(httptest.NewRequest(http.MethodGet, "/page", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Security-Policy"), "script-src 'self' 'nonce-")
+	require.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+	require.NotEmpty(t, w.Header().Get("Strict-Transport-Security"))
+
+	token := CSRFToken(secret, "")
+
+	w = do(httptest.NewRequest(http.MethodPost, "/action", nil))
+	require.Equal(t, http.StatusForbidden, w.Code, "no token")
+
+	req := httptest.NewRequest(http.MethodPost, "/action", nil)
+	req.Header.Set(CSRFHeader, "wrong")
+	require.Equal(t, http.StatusForbidden, do(req).Code, "wrong token")
+
+	req = httptest.NewRequest(http.MethodPost, "/action", nil)
+	req.Header.Set(CSRFHeader, token)
+	require.Equal(t, http.StatusOK, do(req).Code, "token in header")
+
+	req = httptest.NewRequest(http.MethodPost, "/action",
+		strings.NewReader(url.Values{CSRFFormField: {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.Equal(t, http.StatusOK, do(req).Code, "token in form")
+
+	w = do(httptest.NewRequest(http.MethodGet, "/api", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Content-Security-Policy"), "route without csp")
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"), "other headers are still set")
+
+	w = do(httptest.NewRequest(http.MethodPost, "/api", nil))
+	require.Equal(t, http.StatusForbidden, w.Code, "csp opt-out does not skip csrf check")
+
+	require.NotEqual(t, CSRFToken(secret, "a"), CSRFToken(secret, "b"))
+}
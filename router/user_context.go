@@ -7,6 +7,9 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/oauth2"
+
+	"ddm-admin-console/logging"
+	"ddm-admin-console/tracing"
 )
 
 const (
@@ -17,6 +20,7 @@ const (
 	CanViewClusterManagementSessionKey = "can-view-cluster-management"
 	CanViewRegistriesSessionKey        = "can-view-registries"
 	CanCreateRegistriesSessionKey      = "can-create-registries"
+	RolesSessionKey                    = "console-roles"
 )
 
 var ErrTokenNotFound = errors.New("token not found")
@@ -37,13 +41,20 @@ func ExtractToken(ctx *gin.Context) (*oauth2.Token, error) {
 	return tokenData, nil
 }
 
+// ContextWithUserAccessToken returns context with access token of user, it is not canceled with request
+// but keeps request span, so calls made with it are traced as part of request
 func ContextWithUserAccessToken(ctx *gin.Context) context.Context {
 	tokenData, err := ExtractToken(ctx)
 	if err != nil {
-		return context.Background()
+		return requestContext(ctx)
 	}
 
-	return ContextWithUserAccessTokenString(tokenData.AccessToken)
+	return context.WithValue(requestContext(ctx), AuthTokenSessionKey, tokenData.AccessToken)
+}
+
+// requestContext is detached from request cancellation, but keeps its span and log fields
+func requestContext(ctx *gin.Context) context.Context {
+	return logging.WithFields(tracing.Detach(ctx), logging.FromContext(ctx.Request.Context()))
 }
 
 func ContextWithUserAccessTokenString(token string) context.Context {
@@ -59,7 +70,7 @@ func UserDataMiddleware(ctx *gin.Context) {
 
 	session := sessions.Default(ctx)
 	vars := []string{UserNameSessionKey, CanViewClusterManagementSessionKey, CanViewRegistriesSessionKey,
-		CanCreateRegistriesSessionKey, UserEmailSessionKey}
+		CanCreateRegistriesSessionKey, UserEmailSessionKey, RolesSessionKey}
 
 	for _, v := range vars {
 		val := session.Get(v)
@@ -68,5 +79,7 @@ func UserDataMiddleware(ctx *gin.Context) {
 		}
 	}
 
+	logging.FromContext(ctx.Request.Context()).User = ctx.GetString(UserEmailSessionKey)
+
 	ctx.Next()
 }
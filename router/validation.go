@@ -36,6 +36,21 @@ func (r *Router) parseValidationErrors(params gin.H) gin.H {
 	return params
 }
 
+// fieldErrorMessages groups validation errors by field, error param is used as message when it is set
+func fieldErrorMessages(validationErrors validator.ValidationErrors) map[string][]string {
+	messages := make(map[string][]string)
+	for _, vErr := range validationErrors {
+		msg := vErr.Param()
+		if msg == "" {
+			msg = vErr.Tag()
+		}
+
+		messages[vErr.Field()] = append(messages[vErr.Field()], msg)
+	}
+
+	return messages
+}
+
 func (r *Router) AddValidator(tag string, valid validator.Func) error {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		if err := v.RegisterValidation(tag, valid); err != nil {
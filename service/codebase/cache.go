@@ -0,0 +1,54 @@
+package codebase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"ddm-admin-console/service"
+)
+
+const (
+	IndexCodebaseType   = "spec.type"
+	IndexBranchCodebase = "spec.codebaseName"
+)
+
+// UseCache switches codebases and branches reads of service to shared informer cache of controllers
+// manager, services created for user context keep reading from api server to respect user permissions
+func (s *Service) UseCache(ctx context.Context, informerCache cache.Cache) error {
+	if err := service.IndexInformerCache(ctx, informerCache,
+		[]client.Object{&Codebase{}, &CodebaseBranch{}},
+		[]service.FieldIndex{
+			{
+				Object: &Codebase{},
+				Field:  IndexCodebaseType,
+				Extract: func(obj client.Object) []string {
+					if cb, ok := obj.(*Codebase); ok {
+						return []string{cb.Spec.Type}
+					}
+
+					return nil
+				},
+			},
+			{
+				Object: &CodebaseBranch{},
+				Field:  IndexBranchCodebase,
+				Extract: func(obj client.Object) []string {
+					if br, ok := obj.(*CodebaseBranch); ok {
+						return []string{br.Spec.CodebaseName}
+					}
+
+					return nil
+				},
+			},
+		}); err != nil {
+		return errors.Wrap(err, "unable to index codebase informer cache")
+	}
+
+	s.reader = informerCache
+	s.indexed = true
+
+	return nil
+}
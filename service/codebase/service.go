@@ -28,6 +28,8 @@ const (
 type Service struct {
 	service.UserConfig
 	k8sClient  client.Client
+	reader     client.Reader
+	indexed    bool
 	scheme     *runtime.Scheme
 	namespace  string
 	restConfig *rest.Config
@@ -65,6 +67,7 @@ func Make(sch *runtime.Scheme, k8sConfig *rest.Config, namespace string) (*Servi
 
 	return &Service{
 		k8sClient: cl,
+		reader:    cl,
 		scheme:    sch,
 		namespace: namespace,
 		UserConfig: service.UserConfig{
@@ -76,7 +79,7 @@ func Make(sch *runtime.Scheme, k8sConfig *rest.Config, namespace string) (*Servi
 
 func (s *Service) GetAll() ([]Codebase, error) {
 	var lst CodebaseList
-	if err := s.k8sClient.List(context.Background(), &lst, &client.ListOptions{Namespace: s.namespace}); err != nil {
+	if err := s.reader.List(context.Background(), &lst, &client.ListOptions{Namespace: s.namespace}); err != nil {
 		return nil, errors.Wrap(err, "unable to get codebases")
 	}
 
@@ -84,13 +87,18 @@ func (s *Service) GetAll() ([]Codebase, error) {
 }
 
 func (s *Service) GetAllByType(tp string) ([]Codebase, error) {
-	all, err := s.GetAll()
-	if err != nil {
+	opts := []client.ListOption{&client.ListOptions{Namespace: s.namespace}}
+	if s.indexed {
+		opts = append(opts, client.MatchingFields{IndexCodebaseType: tp})
+	}
+
+	var all CodebaseList
+	if err := s.reader.List(context.Background(), &all, opts...); err != nil {
 		return nil, errors.Wrap(err, "unable to get all codebases")
 	}
 
-	result := make([]Codebase, 0, len(all))
-	for _, v := range all {
+	result := make([]Codebase, 0, len(all.Items))
+	for _, v := range all.Items {
 		if v.Spec.Type == tp {
 			result = append(result, v)
 		}
@@ -101,7 +109,7 @@ func (s *Service) GetAllByType(tp string) ([]Codebase, error) {
 
 func (s *Service) Get(name string) (*Codebase, error) {
 	var cb Codebase
-	if err := s.k8sClient.Get(context.Background(), types.NamespacedName{Namespace: s.namespace, Name: name}, &cb); err != nil {
+	if err := s.reader.Get(context.Background(), types.NamespacedName{Namespace: s.namespace, Name: name}, &cb); err != nil {
 		return nil, errors.Wrapf(err, "unable to get codebase: %s", name)
 	}
 
@@ -155,7 +163,7 @@ func (s *Service) Delete(name string) error {
 
 func (s *Service) GetAllBranches(ctx context.Context) ([]CodebaseBranch, error) {
 	var lst CodebaseBranchList
-	if err := s.k8sClient.List(ctx, &lst, &client.ListOptions{Namespace: s.namespace}); err != nil {
+	if err := s.reader.List(ctx, &lst, &client.ListOptions{Namespace: s.namespace}); err != nil {
 		return nil, errors.Wrap(err, "unable to get all codebase branches")
 	}
 
@@ -163,13 +171,18 @@ func (s *Service) GetAllBranches(ctx context.Context) ([]CodebaseBranch, error)
 }
 
 func (s *Service) GetBranchesByCodebase(ctx context.Context, codebaseName string) ([]CodebaseBranch, error) {
-	branches, err := s.GetAllBranches(ctx)
-	if err != nil {
+	opts := []client.ListOption{&client.ListOptions{Namespace: s.namespace}}
+	if s.indexed {
+		opts = append(opts, client.MatchingFields{IndexBranchCodebase: codebaseName})
+	}
+
+	var branches CodebaseBranchList
+	if err := s.reader.List(ctx, &branches, opts...); err != nil {
 		return nil, errors.Wrap(err, "unable to get all branches")
 	}
 
-	filteredBranches := make([]CodebaseBranch, 0, len(branches))
-	for _, br := range branches {
+	filteredBranches := make([]CodebaseBranch, 0, len(branches.Items))
+	for _, br := range branches.Items {
 		if br.Spec.CodebaseName == codebaseName {
 			filteredBranches = append(filteredBranches, br)
 		}
@@ -26,6 +26,12 @@ const (
 	StatusAnnotationInactiveBranches                = "inactive-branches"
 	StatusAnnotationRunningJobs                     = "running-jobs"
 	StatusInactive                                  = "inactive"
+	DeletionRequestedAtAnnotation                   = "registry-deletion/requested-at"
+	DeletionRequestedByAnnotation                   = "registry-deletion/requested-by"
+	DeletionReportAnnotation                        = "registry-deletion/report"
+	VersionAnnotation                               = "registry-version/effective"
+	VersionUpgradedAtAnnotation                     = "registry-version/upgraded-at"
+	VersionSourceAnnotation                         = "registry-version/source"
 )
 
 var (
@@ -128,7 +134,27 @@ func (in *Codebase) StrStatus() string {
 }
 
 func (in *Codebase) Available() bool {
-	return !in.ForegroundDeletion() && in.StrStatus() != "failed"
+	return !in.ForegroundDeletion() && !in.PendingDeletion() && in.StrStatus() != "failed"
+}
+
+func (in *Codebase) PendingDeletion() bool {
+	_, ok := in.Annotations[DeletionRequestedAtAnnotation]
+	return ok
+}
+
+// DeletionRequestedAt returns the time when registry was marked for deletion
+func (in *Codebase) DeletionRequestedAt() (time.Time, bool) {
+	requestedAt, ok := in.Annotations[DeletionRequestedAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, requestedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
 }
 
 func (in *Codebase) LocaleStatus() string {
@@ -224,6 +250,21 @@ type Codebase struct {
 	Version  *version.Version `json:"version,omitempty"`
 }
 
+// StoredVersion returns effective registry version precomputed by codebase controller
+func (in *Codebase) StoredVersion() (*version.Version, bool) {
+	val, ok := in.Annotations[VersionAnnotation]
+	if !ok {
+		return nil, false
+	}
+
+	v, err := version.NewVersion(val)
+	if err != nil {
+		return nil, false
+	}
+
+	return v, true
+}
+
 func (in *Codebase) Admins() string {
 	if in.Annotations != nil && in.Annotations[AdminsAnnotation] != "" {
 		admins, err := base64.StdEncoding.DecodeString(in.Annotations[AdminsAnnotation])
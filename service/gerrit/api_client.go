@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -12,6 +13,9 @@ import (
 	"gopkg.in/resty.v1"
 	coreV1Api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	"ddm-admin-console/resilience"
+	"ddm-admin-console/tracing"
 )
 
 func (s *Service) initRestyClient() error {
@@ -30,11 +34,16 @@ func (s *Service) initRestyClient() error {
 	gerritURL := strings.ReplaceAll(s.GerritAPIUrlTemplate, "{HOST}",
 		fmt.Sprintf("%s.%s", s.RootGerritName, s.Namespace))
 
+	transport := resilience.Transport("gerrit", s.ClientPolicy, tracing.Transport(http.DefaultTransport))
+
 	s.apiClient = resty.New().
+		SetTransport(transport).
 		SetHostURL(gerritURL).
 		SetBasicAuth(string(secret.Data["user"]), string(secret.Data["password"])).
 		SetDisableWarn(true)
 
+	s.goGerritHTTPClient = &http.Client{Transport: transport}
+
 	var err error
 	s.goGerritClient, err = goGerrit.NewClient(strings.ReplaceAll(gerritURL, "/a/", "/"), s.goGerritHTTPClient)
 	if err != nil {
@@ -61,17 +70,6 @@ func checkErr(rsp *resty.Response, err error) error {
 	return nil
 }
 
-// GetFileFromBranch gets the content of a file from the HEAD revision of a certain branch.
-// The content is returned as base64 encoded string.
-func (s *Service) GetFileFromBranch(projectName, branch, fileLocation string) (string, error) {
-	content, _, err := s.GoGerritClient().Projects.GetBranchContent(projectName, branch, fileLocation)
-	if err != nil {
-		return "", fmt.Errorf("unable to get branch content: %w", err)
-	}
-
-	return content, nil
-}
-
 func (s *Service) GetFileContents(ctx context.Context, projectName, branch, filePath string) (string, error) {
 	filePath = url.PathEscape(filePath)
 	path := fmt.Sprintf("projects/%s/branches/%s/files/%s/content", projectName, branch, filePath)
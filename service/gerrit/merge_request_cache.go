@@ -0,0 +1,113 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"ddm-admin-console/service"
+)
+
+const (
+	MRLabelTarget = "console/target"
+
+	IndexMRProject  = "spec.projectName"
+	IndexMRTarget   = "metadata.labels.target"
+	IndexMRStatus   = "status.value"
+	IndexMRChangeID = "status.changeId"
+)
+
+// MergeRequestFilter selects merge requests, empty fields match any value
+type MergeRequestFilter struct {
+	Project string
+	Target  string
+	Status  string
+}
+
+func (f MergeRequestFilter) Match(mr *GerritMergeRequest) bool {
+	return (f.Project == "" || mr.Spec.ProjectName == f.Project) &&
+		(f.Target == "" || mr.Labels[MRLabelTarget] == f.Target) &&
+		(f.Status == "" || mr.Status.Value == f.Status)
+}
+
+// indexField returns the most selective indexed field of filter
+func (f MergeRequestFilter) indexField() (string, string) {
+	switch {
+	case f.Project != "":
+		return IndexMRProject, f.Project
+	case f.Target != "":
+		return IndexMRTarget, f.Target
+	case f.Status != "":
+		return IndexMRStatus, f.Status
+	}
+
+	return "", ""
+}
+
+func mergeRequestIndexes() []service.FieldIndex {
+	index := func(field string, extract func(mr *GerritMergeRequest) string) service.FieldIndex {
+		return service.FieldIndex{
+			Object: &GerritMergeRequest{},
+			Field:  field,
+			Extract: func(obj client.Object) []string {
+				mr, ok := obj.(*GerritMergeRequest)
+				if !ok {
+					return nil
+				}
+
+				if val := extract(mr); val != "" {
+					return []string{val}
+				}
+
+				return nil
+			},
+		}
+	}
+
+	return []service.FieldIndex{
+		index(IndexMRProject, func(mr *GerritMergeRequest) string { return mr.Spec.ProjectName }),
+		index(IndexMRTarget, func(mr *GerritMergeRequest) string { return mr.Labels[MRLabelTarget] }),
+		index(IndexMRStatus, func(mr *GerritMergeRequest) string { return mr.Status.Value }),
+		index(IndexMRChangeID, func(mr *GerritMergeRequest) string { return mr.Status.ChangeID }),
+	}
+}
+
+// UseCache switches merge requests and projects reads of service to shared informer cache of controllers manager
+func (s *Service) UseCache(ctx context.Context, informerCache cache.Cache) error {
+	if err := service.IndexInformerCache(ctx, informerCache,
+		[]client.Object{&GerritMergeRequest{}, &GerritProject{}}, mergeRequestIndexes()); err != nil {
+		return fmt.Errorf("unable to index gerrit informer cache, %w", err)
+	}
+
+	s.reader = informerCache
+	s.indexed = true
+
+	return nil
+}
+
+func (s *Service) FindMergeRequests(ctx context.Context, filter MergeRequestFilter) ([]GerritMergeRequest, error) {
+	var (
+		mrs  GerritMergeRequestList
+		opts []client.ListOption
+	)
+
+	// without informer cache field selectors are not supported by custom resources api
+	if field, val := filter.indexField(); s.indexed && field != "" {
+		opts = append(opts, client.MatchingFields{field: val})
+	}
+
+	if err := s.reader.List(ctx, &mrs, opts...); err != nil {
+		return nil, fmt.Errorf("unable to list gerrit merge requests, %w", err)
+	}
+
+	result := make([]GerritMergeRequest, 0, len(mrs.Items))
+	for i := range mrs.Items {
+		if filter.Match(&mrs.Items[i]) {
+			result = append(result, mrs.Items[i])
+		}
+	}
+
+	return result, nil
+}
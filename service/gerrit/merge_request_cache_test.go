@@ -0,0 +1,30 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeRequestFilter(t *testing.T) {
+	t.Parallel()
+
+	mr := GerritMergeRequest{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{MRLabelTarget: "registry-version-update"}},
+		Spec:       GerritMergeRequestSpec{ProjectName: "registry"},
+		Status:     GerritMergeRequestStatus{Value: StatusMerged},
+	}
+
+	require.True(t, MergeRequestFilter{}.Match(&mr))
+	require.True(t, MergeRequestFilter{Project: "registry", Status: StatusMerged}.Match(&mr))
+	require.False(t, MergeRequestFilter{Project: "registry", Status: StatusNew}.Match(&mr))
+	require.False(t, MergeRequestFilter{Target: "registry-edit"}.Match(&mr))
+
+	field, val := MergeRequestFilter{Target: "t", Status: StatusNew}.indexField()
+	require.Equal(t, IndexMRTarget, field)
+	require.Equal(t, "t", val)
+
+	field, _ = MergeRequestFilter{}.indexField()
+	require.Empty(t, field)
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"ddm-admin-console/service"
 	"ddm-admin-console/locale"
+	"ddm-admin-console/logging"
+	"ddm-admin-console/resilience"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"path/filepath"
 	"time"
@@ -40,6 +41,8 @@ type Service struct {
 	Config
 	service.UserConfig
 	k8sClient          client.Client
+	reader             client.Reader
+	indexed            bool
 	scheme             *runtime.Scheme
 	restConfig         *rest.Config
 	apiClient          *resty.Client
@@ -69,6 +72,7 @@ type Config struct {
 	Namespace            string
 	RootGerritName       string
 	GerritAPIUrlTemplate string
+	ClientPolicy         resilience.Policy
 }
 
 func Make(s *runtime.Scheme, k8sConfig *rest.Config, config Config) (*Service, error) {
@@ -89,6 +93,7 @@ func Make(s *runtime.Scheme, k8sConfig *rest.Config, config Config) (*Service, e
 
 	svc := Service{
 		k8sClient: cl,
+		reader:    cl,
 		scheme:    s,
 		UserConfig: service.UserConfig{
 			RestConfig: k8sConfig,
@@ -106,7 +111,7 @@ func Make(s *runtime.Scheme, k8sConfig *rest.Config, config Config) (*Service, e
 
 func (s *Service) GetProjects(ctx context.Context) ([]GerritProject, error) {
 	var projectList GerritProjectList
-	if err := s.k8sClient.List(ctx, &projectList); err != nil {
+	if err := s.reader.List(ctx, &projectList); err != nil {
 		return nil, fmt.Errorf("unable to list gerrit projects, %w", err)
 	}
 
@@ -147,17 +152,12 @@ func (s *Service) CreateProject(ctx context.Context, name string) error {
 }
 
 func (s *Service) GetMergeRequests(ctx context.Context) ([]GerritMergeRequest, error) {
-	var mrs GerritMergeRequestList
-	if err := s.k8sClient.List(ctx, &mrs); err != nil {
-		return nil, fmt.Errorf("unable to get merge requests, %w", err)
-	}
-
-	return mrs.Items, nil
+	return s.FindMergeRequests(ctx, MergeRequestFilter{})
 }
 
 func (s *Service) GetMergeRequest(ctx context.Context, name string) (*GerritMergeRequest, error) {
 	var mr GerritMergeRequest
-	if err := s.k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: s.Namespace}, &mr); err != nil {
+	if err := s.reader.Get(ctx, types.NamespacedName{Name: name, Namespace: s.Namespace}, &mr); err != nil {
 		return nil, fmt.Errorf("unable to get gerrit merge request, %w", err)
 	}
 
@@ -183,8 +183,16 @@ func (s *Service) GetProjectInfo(projectName string) (*goGerrit.ProjectInfo, err
 }
 
 func (s *Service) GetMergeRequestByChangeID(ctx context.Context, changeID string) (*GerritMergeRequest, error) {
-	var mrs GerritMergeRequestList
-	if err := s.k8sClient.List(ctx, &mrs); err != nil {
+	var (
+		mrs  GerritMergeRequestList
+		opts []client.ListOption
+	)
+
+	if s.indexed {
+		opts = append(opts, client.MatchingFields{IndexMRChangeID: changeID})
+	}
+
+	if err := s.reader.List(ctx, &mrs, opts...); err != nil {
 		return nil, fmt.Errorf("unable to list gerrit merge requests, %w", err)
 	}
 
@@ -206,25 +214,13 @@ func (s *Service) UpdateMergeRequestStatus(ctx context.Context, mr *GerritMergeR
 }
 
 func (s *Service) GetMergeRequestByProject(ctx context.Context, projectName string) ([]GerritMergeRequest, error) {
-	var mrs GerritMergeRequestList
-	if err := s.k8sClient.List(ctx, &mrs); err != nil {
-		return nil, fmt.Errorf("unable to list gerrit merge requests, %w", err)
-	}
-
-	var result []GerritMergeRequest
-	for _, mr := range mrs.Items {
-		if mr.Spec.ProjectName == projectName {
-			result = append(result, mr)
-		}
-	}
-
-	return result, nil
+	return s.FindMergeRequests(ctx, MergeRequestFilter{Project: projectName})
 }
 
 func (s *Service) CreateMergeRequest(ctx context.Context, mr *MergeRequest) error {
 	if err := s.k8sClient.Create(ctx, &GerritMergeRequest{
 		ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace, Name: mr.Name, Labels: mr.Labels,
-			Annotations: mr.Annotations},
+			Annotations: logging.FromContext(ctx).Annotate(mr.Annotations)},
 		Spec: GerritMergeRequestSpec{
 			OwnerName:           s.RootGerritName,
 			ProjectName:         mr.ProjectName,
@@ -266,7 +262,7 @@ func (s *Service) CreateMergeRequestWithContents(ctx context.Context, mr *MergeR
 
 	if err := s.k8sClient.Create(ctx, &GerritMergeRequest{
 		ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace, Name: mr.Name, Labels: mr.Labels,
-			Annotations: mr.Annotations},
+			Annotations: logging.FromContext(ctx).Annotate(mr.Annotations)},
 		Spec: GerritMergeRequestSpec{
 			OwnerName:        s.RootGerritName,
 			ProjectName:      mr.ProjectName,
@@ -283,31 +279,3 @@ func (s *Service) CreateMergeRequestWithContents(ctx context.Context, mr *MergeR
 
 	return nil
 }
-
-func (s *Service) ApproveAndSubmitChange(changeID, username, email string) error {
-	if _, rsp, err := s.GoGerritClient().Changes.SetReview(changeID, CurrentRevision, &goGerrit.ReviewInput{
-		Message: fmt.Sprintf("Submitted by %s [%s]", username, email),
-		Labels: map[string]string{
-			"Code-Review": "2",
-			"Verified":    "1",
-		},
-	}); err != nil {
-		if rsp != nil {
-			body, _ := io.ReadAll(rsp.Body)
-			return errors.Wrapf(err, "unable to review change, error: %s", string(body))
-		}
-
-		return fmt.Errorf("unable to review change, %w", err)
-	}
-
-	if _, rsp, err := s.GoGerritClient().Changes.SubmitChange(changeID, &goGerrit.SubmitInput{}); err != nil {
-		if rsp != nil {
-			body, _ := io.ReadAll(rsp.Body)
-			return errors.Wrapf(err, "unable to submit change, error: %s", string(body))
-		}
-
-		return fmt.Errorf("unable to submit change, %w", err)
-	}
-
-	return nil
-}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+)
+
+// MirrorCache keeps persistent bare mirrors of projects, so repositories are fetched incrementally
+// instead of being cloned on every use
+type MirrorCache struct {
+	root  string
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func MakeMirrorCache(root string) *MirrorCache {
+	return &MirrorCache{
+		root:  root,
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock serializes work with project mirror and its worktrees, returned func releases the lock
+func (m *MirrorCache) Lock(projectName string) func() {
+	m.mu.Lock()
+	l, ok := m.locks[projectName]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[projectName] = l
+	}
+	m.mu.Unlock()
+
+	l.Lock()
+
+	return l.Unlock
+}
+
+func (m *MirrorCache) Path(projectName string) string {
+	return path.Join(m.root, fmt.Sprintf("%s.git", projectName))
+}
+
+// CloneWorktree creates or updates project mirror and checks out new worktree of it to service path,
+// caller must hold project lock
+func (s *Service) CloneWorktree(mirrorPath, url string) error {
+	keyPath, err := s.keyFilePath()
+	if err != nil {
+		return fmt.Errorf("unable to create key file, %w", err)
+	}
+
+	if _, err := os.Stat(path.Join(mirrorPath, "HEAD")); err != nil {
+		if err := s.initMirror(mirrorPath, url, keyPath); err != nil {
+			return fmt.Errorf("unable to init mirror, %w", err)
+		}
+	}
+
+	// worktrees left by interrupted reconciles keep branches locked
+	pruneCMD := s.commandCreate("git", "--git-dir", mirrorPath, "worktree", "prune")
+	if bts, err := pruneCMD.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to prune worktrees: %s, %w", string(bts), err)
+	}
+
+	fetchCMD := s.commandCreate("git", "--git-dir", mirrorPath, "fetch", "--prune", "--force", "origin")
+	fetchCMD.SetEnv(s.authEnv(keyPath))
+
+	if bts, err := fetchCMD.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to fetch mirror: %s, %w", string(bts), err)
+	}
+
+	worktreeCMD := s.commandCreate("git", "--git-dir", mirrorPath, "worktree", "add", "--detach", "--force",
+		s.path, "HEAD")
+	if bts, err := worktreeCMD.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to add worktree: %s, %w", string(bts), err)
+	}
+
+	return nil
+}
+
+// RemoveWorktree removes worktree checked out by CloneWorktree together with service temp files
+func (s *Service) RemoveWorktree(mirrorPath string) error {
+	cmd := s.commandCreate("git", "--git-dir", mirrorPath, "worktree", "remove", "--force", s.path)
+	if bts, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to remove worktree: %s, %w", string(bts), err)
+	}
+
+	return s.Clean()
+}
+
+func (s *Service) initMirror(mirrorPath, url, keyPath string) error {
+	if err := os.RemoveAll(mirrorPath); err != nil {
+		return fmt.Errorf("unable to clear mirror folder, %w", err)
+	}
+
+	if err := os.MkdirAll(path.Dir(mirrorPath), 0o777); err != nil {
+		return fmt.Errorf("unable to create mirrors folder, %w", err)
+	}
+
+	cloneCMD := s.commandCreate("git", "clone", "--bare", url, mirrorPath)
+	cloneCMD.SetEnv(s.authEnv(keyPath))
+
+	if bts, err := cloneCMD.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to clone repo: %s, %w", string(bts), err)
+	}
+
+	// only branches are mirrored, gerrit changes refs are fetched on demand
+	cmd := s.commandCreate("git", "--git-dir", mirrorPath, "config", "--local",
+		"remote.origin.fetch", "+refs/heads/*:refs/heads/*")
+	if bts, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to configure mirror: %s, %w", string(bts), err)
+	}
+
+	return nil
+}
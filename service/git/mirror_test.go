@@ -0,0 +1,100 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorCache_Lock(t *testing.T) {
+	t.Parallel()
+
+	m := MakeMirrorCache(t.TempDir())
+	unlock := m.Lock("registry")
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		entered []string
+	)
+
+	for _, prj := range []string{"registry", "other"} {
+		wg.Add(1)
+		go func(prj string) {
+			defer wg.Done()
+			defer m.Lock(prj)()
+
+			mu.Lock()
+			entered = append(entered, prj)
+			mu.Unlock()
+		}(prj)
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(entered) == 1
+	}, time.Second, time.Millisecond*10)
+	require.Equal(t, []string{"other"}, entered)
+
+	unlock()
+	wg.Wait()
+	require.Equal(t, []string{"other", "registry"}, entered)
+}
+
+func TestService_CloneWorktree(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tmp := t.TempDir()
+	origin := path.Join(tmp, "origin.git")
+	work := path.Join(tmp, "work")
+	runGit(t, "", "init", "--bare", "-b", "master", origin)
+	runGit(t, "", "clone", origin, work)
+	require.NoError(t, os.WriteFile(path.Join(work, "values.yaml"), []byte("a: 1\n"), 0o600))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-m", "init")
+	runGit(t, work, "push", "origin", "master")
+
+	mirrors := MakeMirrorCache(path.Join(tmp, "mirrors"))
+	mirrorPath := mirrors.Path("origin")
+
+	for i, contents := range []string{"a: 1\n", "a: 2\n", "a: 3\n"} {
+		s := Make(path.Join(tmp, "wt", string(rune('a'+i))), "user", "key")
+		s.TempDir = tmp
+
+		require.NoError(t, s.CloneWorktree(mirrorPath, origin))
+
+		got, err := s.GetFileContents("values.yaml")
+		require.NoError(t, err)
+		require.Equal(t, contents, got)
+
+		require.NoError(t, s.RawCheckout("next", true))
+		require.NoError(t, s.SetFileContents("values.yaml", fmt.Sprintf("a: %d\n", i+2)))
+		require.NoError(t, s.Add("."))
+		require.NoError(t, s.RawCommit(&User{Name: "test", Email: "test@example.com"}, "update"))
+		require.NoError(t, s.Push("origin", "HEAD:refs/heads/master", "--force"))
+		require.NoError(t, s.RemoveWorktree(mirrorPath))
+		require.NoDirExists(t, s.path)
+
+		runGit(t, "", "--git-dir", mirrorPath, "branch", "-D", "next")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	bts, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(bts))
+}
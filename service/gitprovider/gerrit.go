@@ -0,0 +1,231 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	goGerrit "github.com/andygrunwald/go-gerrit"
+)
+
+const (
+	gerritCurrentRevision = "current"
+	gerritCommitMessage   = "/COMMIT_MSG"
+	gerritMergeList       = "/MERGE_LIST"
+)
+
+type gerritProvider struct {
+	client *goGerrit.Client
+}
+
+// MakeGerrit returns provider of Gerrit changes made through authenticated client
+func MakeGerrit(client *goGerrit.Client) Provider {
+	return &gerritProvider{client: client}
+}
+
+func (g *gerritProvider) Name() string {
+	return Gerrit
+}
+
+func (g *gerritProvider) AbandonChange(_ context.Context, changeID string, author Author) error {
+	if _, rsp, err := g.client.Changes.AbandonChange(changeID, &goGerrit.AbandonInput{
+		Message: fmt.Sprintf("Abandoned by %s [%s]", author.Name, author.Email),
+	}); err != nil {
+		return gerritErr(rsp, fmt.Errorf("unable to abandon change, %w", err))
+	}
+
+	return nil
+}
+
+func (g *gerritProvider) ApproveAndSubmitChange(_ context.Context, changeID string, author Author) error {
+	if _, rsp, err := g.client.Changes.SetReview(changeID, gerritCurrentRevision, &goGerrit.ReviewInput{
+		Message: fmt.Sprintf("Submitted by %s [%s]", author.Name, author.Email),
+		Labels: map[string]string{
+			"Code-Review": "2",
+			"Verified":    "1",
+		},
+	}); err != nil {
+		return gerritErr(rsp, fmt.Errorf("unable to review change, %w", err))
+	}
+
+	if _, rsp, err := g.client.Changes.SubmitChange(changeID, &goGerrit.SubmitInput{}); err != nil {
+		return gerritErr(rsp, fmt.Errorf("unable to submit change, %w", err))
+	}
+
+	return nil
+}
+
+func (g *gerritProvider) GetBranches(_ context.Context, project string) ([]Branch, error) {
+	infos, rsp, err := g.client.Projects.ListBranches(project, nil)
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to list branches, %w", err))
+	}
+
+	branches := make([]Branch, 0, len(*infos))
+	for _, b := range *infos {
+		if !strings.HasPrefix(b.Ref, "refs/heads/") {
+			continue
+		}
+
+		branches = append(branches, Branch{Name: strings.TrimPrefix(b.Ref, "refs/heads/"), Revision: b.Revision})
+	}
+
+	return branches, nil
+}
+
+func (g *gerritProvider) GetChange(_ context.Context, changeID string) (*Change, error) {
+	info, rsp, err := g.client.Changes.GetChangeDetail(changeID, &goGerrit.ChangeOptions{})
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to get change details, %w", err))
+	}
+
+	return &Change{
+		ID:      info.ID,
+		Project: info.Project,
+		Branch:  info.Branch,
+		Subject: info.Subject,
+		Status:  info.Status,
+		Owner:   info.Owner.Name,
+	}, nil
+}
+
+func (g *gerritProvider) GetChangeFile(_ context.Context, changeID, path string) (string, error) {
+	content, rsp, err := g.getContent(changeContentURL(changeID, path))
+	if err != nil {
+		return "", gerritErr(rsp, fmt.Errorf("unable to get file content, %w", err))
+	}
+
+	return content, nil
+}
+
+func (g *gerritProvider) GetChangeFiles(ctx context.Context, changeID string) ([]ChangedFile, error) {
+	change, err := g.GetChange(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, rsp, err := g.client.Changes.ListFiles(change.ID, gerritCurrentRevision, &goGerrit.FilesOptions{Parent: 1})
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to get change files, %w", err))
+	}
+
+	commit, rsp, err := g.client.Changes.GetCommit(change.ID, gerritCurrentRevision, &goGerrit.CommitOptions{})
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to get change commit, %w", err))
+	}
+
+	if len(commit.Parents) == 0 {
+		return nil, fmt.Errorf("no parent commit for change found")
+	}
+
+	changed := make([]ChangedFile, 0, len(files))
+
+	for path := range files {
+		if path == gerritCommitMessage || path == gerritMergeList {
+			continue
+		}
+
+		f := ChangedFile{Path: path}
+
+		original, rsp, err := g.getContent(fmt.Sprintf("projects/%s/commits/%s/files/%s/content",
+			url.QueryEscape(change.Project), commit.Parents[0].Commit, url.PathEscape(path)))
+		switch {
+		case notFound(rsp):
+			f.Added = true
+		case err != nil:
+			return nil, gerritErr(rsp, fmt.Errorf("unable to get original content of %s, %w", path, err))
+		default:
+			f.Old = original
+		}
+
+		content, rsp, err := g.getContent(changeContentURL(change.ID, path))
+		switch {
+		case notFound(rsp):
+			f.Deleted = true
+		case err != nil:
+			return nil, gerritErr(rsp, fmt.Errorf("unable to get content of %s, %w", path, err))
+		default:
+			f.New = content
+		}
+
+		changed = append(changed, f)
+	}
+
+	return changed, nil
+}
+
+func (g *gerritProvider) GetFile(_ context.Context, project, branch, path string) (string, error) {
+	content, rsp, err := g.getContent(fmt.Sprintf("projects/%s/branches/%s/files/%s/content",
+		url.QueryEscape(project), url.QueryEscape(branch), url.PathEscape(path)))
+	if err != nil {
+		return "", gerritErr(rsp, fmt.Errorf("unable to get branch content, %w", err))
+	}
+
+	return content, nil
+}
+
+func (g *gerritProvider) GetProject(_ context.Context, name string) (*Project, error) {
+	info, rsp, err := g.client.Projects.GetProject(name)
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to get project, %w", err))
+	}
+
+	head, rsp, err := g.client.Projects.GetHEAD(name)
+	if err != nil {
+		return nil, gerritErr(rsp, fmt.Errorf("unable to get project HEAD, %w", err))
+	}
+
+	return &Project{Name: info.Name, DefaultBranch: strings.TrimPrefix(head, "refs/heads/")}, nil
+}
+
+// getContent returns decoded file content, Gerrit sends it as base64 text that go-gerrit content methods
+// try to decode as json
+func (g *gerritProvider) getContent(u string) (string, *goGerrit.Response, error) {
+	req, err := g.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create request, %w", err)
+	}
+
+	var buf bytes.Buffer
+	rsp, err := g.client.Do(req, &buf)
+	if err != nil {
+		return "", rsp, err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(buf.String())
+	if err != nil {
+		return "", rsp, fmt.Errorf("unable to decode content, %w", err)
+	}
+
+	return string(content), rsp, nil
+}
+
+func changeContentURL(changeID, path string) string {
+	return fmt.Sprintf("changes/%s/revisions/%s/files/%s/content", changeID, gerritCurrentRevision, url.PathEscape(path))
+}
+
+func notFound(rsp *goGerrit.Response) bool {
+	return rsp != nil && rsp.StatusCode == http.StatusNotFound
+}
+
+// gerritErr adds body of failed response to error, missing objects are reported as ErrNotFound
+func gerritErr(rsp *goGerrit.Response, err error) error {
+	if rsp == nil {
+		return err
+	}
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w, %s", ErrNotFound, err)
+	}
+
+	if body, readErr := io.ReadAll(rsp.Body); readErr == nil && len(body) > 0 {
+		return fmt.Errorf("%w, response: %s", err, string(body))
+	}
+
+	return err
+}
@@ -0,0 +1,115 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goGerrit "github.com/andygrunwald/go-gerrit"
+	"github.com/stretchr/testify/require"
+)
+
+func gerritContent(w http.ResponseWriter, content string) {
+	_, _ = fmt.Fprint(w, base64.StdEncoding.EncodeToString([]byte(content)))
+}
+
+func TestGerritProvider(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		switch r.URL.EscapedPath() {
+		case "/projects/reg-1":
+			_, _ = fmt.Fprint(w, `)]}'
+{"id":"reg-1","name":"reg-1"}`)
+		case "/projects/reg-1/HEAD":
+			_, _ = fmt.Fprint(w, `)]}'
+"refs/heads/master"`)
+		case "/projects/reg-1/branches/":
+			_, _ = fmt.Fprint(w, `)]}'
+[{"ref":"HEAD","revision":"master"},{"ref":"refs/heads/master","revision":"abc"},
+{"ref":"refs/meta/config","revision":"def"}]`)
+		case "/projects/reg-1/branches/master/files/deploy-templates%2Fvalues.yaml/content":
+			gerritContent(w, "global: {}")
+		case "/changes/reg-1~1/detail", "/changes/reg-1~master~I1/detail":
+			_, _ = fmt.Fprint(w, `)]}'
+{"id":"reg-1~master~I1","project":"reg-1","branch":"master","subject":"edit registry","status":"NEW",
+"owner":{"name":"admin"}}`)
+		case "/changes/reg-1~master~I1/revisions/current/files/":
+			_, _ = fmt.Fprint(w, `)]}'
+{"/COMMIT_MSG":{},"deploy-templates/values.yaml":{},"added.yaml":{},"deleted.yaml":{}}`)
+		case "/changes/reg-1~master~I1/revisions/current/commit":
+			_, _ = fmt.Fprint(w, `)]}'
+{"parents":[{"commit":"p1"}]}`)
+		case "/projects/reg-1/commits/p1/files/deploy-templates%2Fvalues.yaml/content":
+			gerritContent(w, "global: {}")
+		case "/projects/reg-1/commits/p1/files/deleted.yaml/content":
+			gerritContent(w, "a: 1")
+		case "/changes/reg-1~master~I1/revisions/current/files/deploy-templates%2Fvalues.yaml/content":
+			gerritContent(w, "global: {replicas: 2}")
+		case "/changes/reg-1~master~I1/revisions/current/files/added.yaml/content":
+			gerritContent(w, "b: 2")
+		case "/changes/reg-1~1/revisions/current/review":
+			_, _ = fmt.Fprint(w, `)]}'
+{}`)
+		case "/changes/reg-1~1/submit":
+			_, _ = fmt.Fprint(w, `)]}'
+{"id":"reg-1~master~I1","status":"MERGED"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := goGerrit.NewClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	p := MakeGerrit(client)
+	ctx := context.Background()
+
+	project, err := p.GetProject(ctx, "reg-1")
+	require.NoError(t, err)
+	require.Equal(t, &Project{Name: "reg-1", DefaultBranch: "master"}, project)
+
+	branches, err := p.GetBranches(ctx, "reg-1")
+	require.NoError(t, err)
+	require.Equal(t, []Branch{{Name: "master", Revision: "abc"}}, branches)
+
+	content, err := p.GetFile(ctx, "reg-1", "master", "deploy-templates/values.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "global: {}", content)
+
+	_, err = p.GetFile(ctx, "reg-2", "master", "deploy-templates/values.yaml")
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	change, err := p.GetChange(ctx, "reg-1~1")
+	require.NoError(t, err)
+	require.Equal(t, &Change{ID: "reg-1~master~I1", Project: "reg-1", Branch: "master", Subject: "edit registry",
+		Status: StatusNew, Owner: "admin"}, change)
+
+	content, err = p.GetChangeFile(ctx, "reg-1~master~I1", "deploy-templates/values.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "global: {replicas: 2}", content)
+
+	files, err := p.GetChangeFiles(ctx, "reg-1~master~I1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ChangedFile{
+		{Path: "deploy-templates/values.yaml", Old: "global: {}", New: "global: {replicas: 2}"},
+		{Path: "added.yaml", New: "b: 2", Added: true},
+		{Path: "deleted.yaml", Old: "a: 1", Deleted: true},
+	}, files)
+
+	requests = nil
+	require.NoError(t, p.ApproveAndSubmitChange(ctx, "reg-1~1", Author{Name: "admin", Email: "admin@example.com"}))
+	require.Equal(t, []string{
+		"POST /changes/reg-1~1/revisions/current/review",
+		"POST /changes/reg-1~1/submit",
+	}, requests)
+}
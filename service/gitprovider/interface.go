@@ -0,0 +1,16 @@
+package gitprovider
+
+import "context"
+
+// Provider is a git hosting which keeps registry repositories and reviews changes of them
+type Provider interface {
+	AbandonChange(ctx context.Context, changeID string, author Author) error
+	ApproveAndSubmitChange(ctx context.Context, changeID string, author Author) error
+	GetBranches(ctx context.Context, project string) ([]Branch, error)
+	GetChange(ctx context.Context, changeID string) (*Change, error)
+	GetChangeFile(ctx context.Context, changeID, path string) (string, error)
+	GetChangeFiles(ctx context.Context, changeID string) ([]ChangedFile, error)
+	GetFile(ctx context.Context, project, branch, path string) (string, error)
+	GetProject(ctx context.Context, name string) (*Project, error)
+	Name() string
+}
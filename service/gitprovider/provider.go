@@ -0,0 +1,37 @@
+package gitprovider
+
+import (
+	"fmt"
+
+	goGerrit "github.com/andygrunwald/go-gerrit"
+
+	"ddm-admin-console/service/gitserver"
+)
+
+type Config struct {
+	// GerritClient is used when git server is Gerrit
+	GerritClient *goGerrit.Client
+}
+
+// ProviderName returns provider of git server, git servers without provider are Gerrit
+func ProviderName(gs *gitserver.GitServer) string {
+	if gs.Spec.GitProvider == "" {
+		return Gerrit
+	}
+
+	return gs.Spec.GitProvider
+}
+
+// Make returns provider of git server
+func Make(gs *gitserver.GitServer, cnf Config) (Provider, error) {
+	switch name := ProviderName(gs); name {
+	case Gerrit:
+		if cnf.GerritClient == nil {
+			return nil, fmt.Errorf("gerrit client is not configured")
+		}
+
+		return MakeGerrit(cnf.GerritClient), nil
+	default:
+		return nil, fmt.Errorf("unknown git provider %s of git server %s", name, gs.Name)
+	}
+}
@@ -0,0 +1,31 @@
+package gitprovider
+
+import (
+	"testing"
+
+	goGerrit "github.com/andygrunwald/go-gerrit"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/gitserver"
+)
+
+func TestMake(t *testing.T) {
+	t.Parallel()
+
+	gs := gitserver.GitServer{ObjectMeta: metav1.ObjectMeta{Name: "gerrit"}}
+
+	_, err := Make(&gs, Config{})
+	require.Error(t, err, "gerrit is the default provider and requires client")
+
+	client, err := goGerrit.NewClient("https://gerrit.example.com", nil)
+	require.NoError(t, err)
+
+	p, err := Make(&gs, Config{GerritClient: client})
+	require.NoError(t, err)
+	require.Equal(t, Gerrit, p.Name())
+
+	gs.Spec.GitProvider = "gitlab"
+	_, err = Make(&gs, Config{GerritClient: client})
+	require.Error(t, err)
+}
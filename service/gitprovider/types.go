@@ -0,0 +1,48 @@
+package gitprovider
+
+import "errors"
+
+const Gerrit = "gerrit"
+
+// change statuses are the ones of Gerrit
+const (
+	StatusNew       = "NEW"
+	StatusMerged    = "MERGED"
+	StatusAbandoned = "ABANDONED"
+)
+
+// ErrNotFound is returned when project, branch, file or change does not exist
+var ErrNotFound = errors.New("not found")
+
+type Author struct {
+	Name  string
+	Email string
+}
+
+type Project struct {
+	Name          string `json:"name"`
+	DefaultBranch string `json:"defaultBranch"`
+}
+
+type Branch struct {
+	Name     string `json:"name"`
+	Revision string `json:"revision"`
+}
+
+type Change struct {
+	ID      string `json:"id"`
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	Subject string `json:"subject"`
+	Status  string `json:"status"`
+	Owner   string `json:"owner"`
+}
+
+// ChangedFile is a file changed by change, content of added file is empty in Old and of deleted one in New
+type ChangedFile struct {
+	Path    string
+	Old     string
+	New     string
+	Added   bool
+	Deleted bool
+}
@@ -19,6 +19,8 @@ type GitServerSpec struct {
 	SshPort                  int32  `json:"sshPort"`
 	NameSshKeySecret         string `json:"nameSshKeySecret"`
 	CreateCodeReviewPipeline bool   `json:"createCodeReviewPipeline"`
+	// GitProvider selects git provider of git server, git servers without provider are gerrit
+	GitProvider string `json:"gitProvider,omitempty"`
 }
 
 // GitServerStatus defines the observed state of GitServer
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldIndex is a field index of informer cache, Extract returns indexed values of object
+type FieldIndex struct {
+	Object  client.Object
+	Field   string
+	Extract client.IndexerFunc
+}
+
+// IndexInformerCache adds field indexes to shared informer cache and requests informers of objects,
+// so they are started and synced together with the cache
+func IndexInformerCache(ctx context.Context, informerCache cache.Cache, objects []client.Object,
+	indexes []FieldIndex) error {
+	for _, idx := range indexes {
+		if err := informerCache.IndexField(ctx, idx.Object, idx.Field, idx.Extract); err != nil {
+			return fmt.Errorf("unable to add index %s, %w", idx.Field, err)
+		}
+	}
+
+	// informers are created lazily, so all of them are requested before cache is started
+	for _, obj := range objects {
+		if _, err := informerCache.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("unable to get informer, %w", err)
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package jenkins
 
 import (
 	"context"
+	"ddm-admin-console/resilience"
 	"ddm-admin-console/service"
 	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/tracing"
 	"net/http"
 
 	"github.com/bndr/gojenkins"
@@ -37,6 +39,7 @@ type Config struct {
 	AdminSecretName string
 	APIUrl          string
 	Namespace       string
+	ClientPolicy    resilience.Policy
 }
 
 func Make(s *runtime.Scheme, k8sConfig *rest.Config, k8s k8s.ServiceInterface, cnf Config) (*Service, error) {
@@ -78,7 +81,9 @@ func (s *Service) initJenkinsAPIClient(ctx context.Context, k8s k8s.ServiceInter
 		return errors.Wrap(err, "unable to get jenkins admin secret")
 	}
 
-	jenkinsClient := gojenkins.CreateJenkins(http.DefaultClient, s.APIUrl, rsp["username"], rsp["password"])
+	jenkinsClient := gojenkins.CreateJenkins(&http.Client{
+		Transport: resilience.Transport("jenkins", s.ClientPolicy, tracing.Transport(http.DefaultTransport)),
+	}, s.APIUrl, rsp["username"], rsp["password"])
 	j, err := jenkinsClient.Init(ctx)
 	if err != nil {
 		return errors.Wrap(err, "unable to init jenkins client")
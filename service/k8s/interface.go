@@ -3,7 +3,10 @@ package k8s
 import (
 	"context"
 
+	appsV1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 type ServiceInterface interface {
@@ -11,9 +14,27 @@ type ServiceInterface interface {
 	GetSecret(name string) (*v1.Secret, error)
 	RecreateSecret(secretName string, data map[string][]byte) error
 	CanI(group, resource, verb, name string) (bool, error)
+	ReviewSelfAccess(ctx context.Context, group, resource, verb, name string) (*AccessReview, error)
+	ReviewSubjectAccess(ctx context.Context, user string, groups []string, group, resource, verb, name string) (*AccessReview, error)
+	ResourceRules(ctx context.Context) (rules []authorizationv1.ResourceRule, incomplete bool, err error)
 	GetSecretFromNamespace(ctx context.Context, name, namespace string) (*v1.Secret, error)
 	GetSecretKey(ctx context.Context, namespace, name, key string) (string, error)
 	GetSecretKeys(ctx context.Context, namespace, name string, keys []string) (map[string]string, error)
+	GetSecrets(ctx context.Context, labelSelector string) ([]v1.Secret, error)
+	ApplySecret(ctx context.Context, secret *v1.Secret) error
+	DeleteSecret(ctx context.Context, name string) error
 	GetConfigMap(ctx context.Context, name, namespace string) (*v1.ConfigMap, error)
 	CreateConfigMap(ctx context.Context, cm *v1.ConfigMap, namespace string) error
+	GetDeployments(ctx context.Context, namespace string) ([]appsV1.Deployment, error)
+	GetStatefulSets(ctx context.Context, namespace string) ([]appsV1.StatefulSet, error)
+	GetPods(ctx context.Context, namespace string) ([]v1.Pod, error)
+	GetResourceQuotas(ctx context.Context, namespace string) ([]v1.ResourceQuota, error)
+	GetPodMetrics(ctx context.Context, namespace string) ([]PodMetrics, error)
+	GetRoles(ctx context.Context) ([]rbacv1.Role, error)
+	GetRoleBindings(ctx context.Context) ([]rbacv1.RoleBinding, error)
+	GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error)
+	ApplyRole(ctx context.Context, role *rbacv1.Role) error
+	ApplyRoleBinding(ctx context.Context, binding *rbacv1.RoleBinding) error
+	DeleteRole(ctx context.Context, name string) error
+	DeleteRoleBinding(ctx context.Context, name string) error
 }
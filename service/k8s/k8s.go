@@ -6,6 +6,7 @@ import (
 	"ddm-admin-console/service"
 
 	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -167,3 +168,110 @@ func (s *Service) CanI(group, resource, verb, name string) (bool, error) {
 
 	return r.Status.Allowed, nil
 }
+
+// AccessReview is a result of access review, reason and evaluation error are reported by authorizers
+type AccessReview struct {
+	Allowed         bool
+	Denied          bool
+	Reason          string
+	EvaluationError string
+}
+
+// ReviewSelfAccess explains access of current user to named object of service namespace
+func (s *Service) ReviewSelfAccess(ctx context.Context, group, resource, verb, name string) (*AccessReview, error) {
+	review := authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: s.resourceAttributes(group, resource, verb, name),
+		},
+	}
+
+	r, err := s.clientSet.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create self subject review")
+	}
+
+	return accessReview(r.Status), nil
+}
+
+// ReviewSubjectAccess explains access of any user to named object of service namespace,
+// groups of user are not resolved by api server and have to be passed explicitly
+func (s *Service) ReviewSubjectAccess(ctx context.Context, user string, groups []string,
+	group, resource, verb, name string) (*AccessReview, error) {
+	review := authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: s.resourceAttributes(group, resource, verb, name),
+			User:               user,
+			Groups:             groups,
+		},
+	}
+
+	r, err := s.clientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, &review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create subject access review")
+	}
+
+	return accessReview(r.Status), nil
+}
+
+func (s *Service) resourceAttributes(group, resource, verb, name string) *authorizationv1.ResourceAttributes {
+	return &authorizationv1.ResourceAttributes{
+		Namespace: s.namespace,
+		Verb:      verb,
+		Group:     group,
+		Resource:  resource,
+		Name:      name,
+	}
+}
+
+func accessReview(status authorizationv1.SubjectAccessReviewStatus) *AccessReview {
+	return &AccessReview{
+		Allowed:         status.Allowed,
+		Denied:          status.Denied,
+		Reason:          status.Reason,
+		EvaluationError: status.EvaluationError,
+	}
+}
+
+// ResourceRules returns rules of current user in service namespace, incomplete is set when rules evaluation
+// was not complete, for example when authorizer does not support rules listing
+func (s *Service) ResourceRules(ctx context.Context) (rules []authorizationv1.ResourceRule, incomplete bool, err error) {
+	review := authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+			Namespace: s.namespace,
+		},
+	}
+
+	r, err := s.clientSet.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to create self subject rules review")
+	}
+
+	return r.Status.ResourceRules, r.Status.Incomplete, nil
+}
+
+func (s *Service) GetDeployments(ctx context.Context, namespace string) ([]appsV1.Deployment, error) {
+	lst, err := s.clientSet.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list deployments, ns: %s", namespace)
+	}
+
+	return lst.Items, nil
+}
+
+func (s *Service) GetStatefulSets(ctx context.Context, namespace string) ([]appsV1.StatefulSet, error) {
+	lst, err := s.clientSet.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list stateful sets, ns: %s", namespace)
+	}
+
+	return lst.Items, nil
+}
+
+func (s *Service) GetPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
+	lst, err := s.clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list pods, ns: %s", namespace)
+	}
+
+	return lst.Items, nil
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodMetrics is a subset of metrics.k8s.io/v1beta1 PodMetrics returned by metrics-server
+type PodMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Containers        []ContainerMetrics `json:"containers"`
+}
+
+type ContainerMetrics struct {
+	Name  string          `json:"name"`
+	Usage v1.ResourceList `json:"usage"`
+}
+
+type podMetricsList struct {
+	Items []PodMetrics `json:"items"`
+}
+
+func (s *Service) GetResourceQuotas(ctx context.Context, namespace string) ([]v1.ResourceQuota, error) {
+	lst, err := s.clientSet.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list resource quotas, ns: %s", namespace)
+	}
+
+	return lst.Items, nil
+}
+
+// GetPodMetrics returns pods usage from metrics-server, fails when metrics API is not installed
+func (s *Service) GetPodMetrics(ctx context.Context, namespace string) ([]PodMetrics, error) {
+	bts, err := s.clientSet.RESTClient().Get().
+		AbsPath(fmt.Sprintf("/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods", namespace)).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get pod metrics, ns: %s", namespace)
+	}
+
+	var lst podMetricsList
+	if err := json.Unmarshal(bts, &lst); err != nil {
+		return nil, errors.Wrap(err, "unable to decode pod metrics")
+	}
+
+	return lst.Items, nil
+}
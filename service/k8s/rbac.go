@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (s *Service) GetRoles(ctx context.Context) ([]rbacv1.Role, error) {
+	lst, err := s.clientSet.RbacV1().Roles(s.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list roles")
+	}
+
+	return lst.Items, nil
+}
+
+func (s *Service) GetRoleBindings(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+	lst, err := s.clientSet.RbacV1().RoleBindings(s.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list role bindings")
+	}
+
+	return lst.Items, nil
+}
+
+func (s *Service) GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+	cr, err := s.clientSet.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster role")
+	}
+
+	return cr, nil
+}
+
+// ApplyRole creates role in service namespace or replaces rules and labels of existing one
+func (s *Service) ApplyRole(ctx context.Context, role *rbacv1.Role) error {
+	roles := s.clientSet.RbacV1().Roles(s.namespace)
+
+	current, err := roles.Get(ctx, role.Name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		if _, err := roles.Create(ctx, role, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "unable to create role")
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "unable to get role")
+	}
+
+	current.Labels = role.Labels
+	current.Rules = role.Rules
+
+	if _, err := roles.Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "unable to update role")
+	}
+
+	return nil
+}
+
+// ApplyRoleBinding creates role binding in service namespace or replaces subjects and labels of existing one,
+// role reference of binding can not be changed
+func (s *Service) ApplyRoleBinding(ctx context.Context, binding *rbacv1.RoleBinding) error {
+	bindings := s.clientSet.RbacV1().RoleBindings(s.namespace)
+
+	current, err := bindings.Get(ctx, binding.Name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		if _, err := bindings.Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "unable to create role binding")
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "unable to get role binding")
+	}
+
+	current.Labels = binding.Labels
+	current.Subjects = binding.Subjects
+
+	if _, err := bindings.Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "unable to update role binding")
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteRole(ctx context.Context, name string) error {
+	err := s.clientSet.RbacV1().Roles(s.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete role")
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
+	err := s.clientSet.RbacV1().RoleBindings(s.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete role binding")
+	}
+
+	return nil
+}
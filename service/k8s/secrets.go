@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (s *Service) GetSecrets(ctx context.Context, labelSelector string) ([]v1.Secret, error) {
+	lst, err := s.clientSet.CoreV1().Secrets(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list secrets")
+	}
+
+	return lst.Items, nil
+}
+
+// ApplySecret creates secret in service namespace or replaces data and labels of existing one
+func (s *Service) ApplySecret(ctx context.Context, secret *v1.Secret) error {
+	secrets := s.clientSet.CoreV1().Secrets(s.namespace)
+
+	current, err := secrets.Get(ctx, secret.Name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "unable to create secret")
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "unable to get secret")
+	}
+
+	current.Labels = secret.Labels
+	current.Data = secret.Data
+
+	if _, err := secrets.Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "unable to update secret")
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteSecret(ctx context.Context, name string) error {
+	err := s.clientSet.CoreV1().Secrets(s.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete secret")
+	}
+
+	return nil
+}
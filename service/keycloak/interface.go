@@ -8,4 +8,5 @@ type ServiceInterface interface {
 	UpdateUser(ctx context.Context, user *KeycloakRealmUser) error
 	DeleteUser(ctx context.Context, user *KeycloakRealmUser) error
 	GetUsersByRealm(ctx context.Context, realmName string) ([]KeycloakRealmUser, error)
+	GetUsersByNamespace(ctx context.Context, namespace, realmName string) ([]KeycloakRealmUser, error)
 }
@@ -76,6 +76,25 @@ func (s *Service) GetUsersByRealm(ctx context.Context, realmName string) ([]Keyc
 	return filteredUsers, nil
 }
 
+func (s *Service) GetUsersByNamespace(ctx context.Context, namespace, realmName string) ([]KeycloakRealmUser, error) {
+	var lst KeycloakRealmUserList
+
+	if err := s.k8sClient.List(ctx, &lst, &client.ListOptions{
+		Namespace: namespace,
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list users")
+	}
+
+	var filteredUsers []KeycloakRealmUser
+	for _, u := range lst.Items {
+		if u.Spec.Realm == realmName {
+			filteredUsers = append(filteredUsers, u)
+		}
+	}
+
+	return filteredUsers, nil
+}
+
 func (s *Service) CreateUser(ctx context.Context, user *KeycloakRealmUser) error {
 	if err := s.k8sClient.Create(ctx, user); err != nil {
 		return errors.Wrap(err, "unable to create realm user")
@@ -0,0 +1,267 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	KindRename  = "rename"
+	KindMove    = "move"
+	KindDrop    = "drop"
+	KindDefault = "default"
+	KindCustom  = "custom"
+
+	pathSeparator = "."
+)
+
+// Step is a single values transform, apply returns true when values were changed
+type Step struct {
+	Kind        string
+	Description string
+	apply       func(values map[string]any) (bool, error)
+}
+
+// Migration is a set of steps that converts values to the structure of the platform version
+type Migration struct {
+	Version *version.Version
+	Steps   []Step
+}
+
+type Migrations []Migration
+
+type StepReport struct {
+	Version     string `json:"version"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Changed     bool   `json:"changed"`
+}
+
+type Report struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Steps []StepReport `json:"steps"`
+}
+
+// Rename renames key at path, the key stays under the same parent
+func Rename(path, newName string) Step {
+	parent, _ := splitPath(path)
+	newPath := newName
+	if parent != "" {
+		newPath = parent + pathSeparator + newName
+	}
+
+	step := Move(path, newPath)
+	step.Kind = KindRename
+	step.Description = fmt.Sprintf("rename %s to %s", path, newPath)
+
+	return step
+}
+
+// Move moves value from one path to another, existing value at destination is replaced
+func Move(from, to string) Step {
+	return Step{
+		Kind:        KindMove,
+		Description: fmt.Sprintf("move %s to %s", from, to),
+		apply: func(values map[string]any) (bool, error) {
+			val, ok := get(values, from)
+			if !ok {
+				return false, nil
+			}
+
+			if err := set(values, to, val); err != nil {
+				return false, err
+			}
+
+			del(values, from)
+
+			return true, nil
+		},
+	}
+}
+
+// Drop removes value at path
+func Drop(path string) Step {
+	return Step{
+		Kind:        KindDrop,
+		Description: fmt.Sprintf("drop %s", path),
+		apply: func(values map[string]any) (bool, error) {
+			return del(values, path), nil
+		},
+	}
+}
+
+// Default sets value at path if it is not set yet
+func Default(path string, value any) Step {
+	return Step{
+		Kind:        KindDefault,
+		Description: fmt.Sprintf("default %s to %v", path, value),
+		apply: func(values map[string]any) (bool, error) {
+			if _, ok := get(values, path); ok {
+				return false, nil
+			}
+
+			if err := set(values, path, value); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		},
+	}
+}
+
+// Custom runs arbitrary transform for changes that can not be described by other steps
+func Custom(description string, fn func(values map[string]any) (bool, error)) Step {
+	return Step{
+		Kind:        KindCustom,
+		Description: description,
+		apply:       fn,
+	}
+}
+
+// Between returns migrations with versions greater than from and lower or equal to "to", in ascending order
+func (ms Migrations) Between(from, to *version.Version) Migrations {
+	var res Migrations
+	for _, m := range ms {
+		if m.Version.GreaterThan(from) && !m.Version.GreaterThan(to) {
+			res = append(res, m)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Version.LessThan(res[j].Version)
+	})
+
+	return res
+}
+
+// Apply applies migrations chain between versions to values
+func (ms Migrations) Apply(values map[string]any, from, to *version.Version) (*Report, error) {
+	report := Report{From: from.Original(), To: to.Original(), Steps: []StepReport{}}
+
+	for _, m := range ms.Between(from, to) {
+		for _, s := range m.Steps {
+			changed, err := s.apply(values)
+			if err != nil {
+				return &report, fmt.Errorf("unable to apply migration %s [%s], %w", m.Version.Original(),
+					s.Description, err)
+			}
+
+			report.Steps = append(report.Steps, StepReport{
+				Version:     m.Version.Original(),
+				Kind:        s.Kind,
+				Description: s.Description,
+				Changed:     changed,
+			})
+		}
+	}
+
+	return &report, nil
+}
+
+// Changed reports whether any migration step changed values
+func (r *Report) Changed() bool {
+	for _, s := range r.Steps {
+		if s.Changed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) String() string {
+	if len(r.Steps) == 0 {
+		return fmt.Sprintf("values migration %s -> %s: no migrations", r.From, r.To)
+	}
+
+	lines := []string{fmt.Sprintf("values migration %s -> %s:", r.From, r.To)}
+	for _, s := range r.Steps {
+		state := "skipped"
+		if s.Changed {
+			state = "applied"
+		}
+
+		lines = append(lines, fmt.Sprintf("- [%s] %s: %s", s.Version, s.Description, state))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func splitPath(path string) (parent, key string) {
+	idx := strings.LastIndex(path, pathSeparator)
+	if idx == -1 {
+		return "", path
+	}
+
+	return path[:idx], path[idx+1:]
+}
+
+func get(values map[string]any, path string) (any, bool) {
+	var current any = values
+	for _, part := range strings.Split(path, pathSeparator) {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func set(values map[string]any, path string, value any) error {
+	parts := strings.Split(path, pathSeparator)
+	current := values
+
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part]
+		if !ok {
+			nextMap := make(map[string]any)
+			current[part] = nextMap
+			current = nextMap
+			continue
+		}
+
+		nextMap, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s is not a map", part)
+		}
+
+		current = nextMap
+	}
+
+	current[parts[len(parts)-1]] = value
+
+	return nil
+}
+
+func del(values map[string]any, path string) bool {
+	parent, key := splitPath(path)
+
+	m := values
+	if parent != "" {
+		p, ok := get(values, parent)
+		if !ok {
+			return false
+		}
+
+		if m, ok = p.(map[string]any); !ok {
+			return false
+		}
+	}
+
+	if _, ok := m[key]; !ok {
+		return false
+	}
+
+	delete(m, key)
+
+	return true
+}
@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_Apply(t *testing.T) {
+	t.Parallel()
+
+	ms := Migrations{
+		{
+			Version: version.Must(version.NewVersion("1.9.7")),
+			Steps: []Step{
+				Default("global.excludePortals", []any{}),
+			},
+		},
+		{
+			Version: version.Must(version.NewVersion("1.9.6")),
+			Steps: []Step{
+				Rename("global.registry.bpms", "bpmsEngine"),
+				Move("keycloak.customHost", "global.keycloak.customHost"),
+				Drop("deprecated"),
+			},
+		},
+		{
+			Version: version.Must(version.NewVersion("1.9.8")),
+			Steps: []Step{
+				Drop("global.language"),
+			},
+		},
+	}
+
+	values := map[string]any{
+		"global": map[string]any{
+			"language": "uk",
+			"registry": map[string]any{
+				"bpms": map[string]any{"replicas": 2},
+			},
+		},
+		"keycloak":   map[string]any{"customHost": "kc.example.com"},
+		"deprecated": true,
+	}
+
+	report, err := ms.Apply(values, version.Must(version.NewVersion("1.9.5")), version.Must(version.NewVersion("1.9.7")))
+	require.NoError(t, err)
+	require.True(t, report.Changed())
+	require.Len(t, report.Steps, 4)
+	require.Equal(t, "1.9.6", report.Steps[0].Version)
+	require.Equal(t, "1.9.7", report.Steps[3].Version)
+
+	require.Equal(t, map[string]any{
+		"global": map[string]any{
+			"language": "uk",
+			"registry": map[string]any{
+				"bpmsEngine": map[string]any{"replicas": 2},
+			},
+			"keycloak":       map[string]any{"customHost": "kc.example.com"},
+			"excludePortals": []any{},
+		},
+		"keycloak": map[string]any{},
+	}, values)
+
+	report, err = ms.Apply(values, version.Must(version.NewVersion("1.9.7")), version.Must(version.NewVersion("1.9.7")))
+	require.NoError(t, err)
+	require.False(t, report.Changed())
+	require.Empty(t, report.Steps)
+}
+
+func TestMove_NotMap(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]any{"a": "b", "c": "d"}
+	_, err := Migrations{{
+		Version: version.Must(version.NewVersion("1.0")),
+		Steps:   []Step{Move("c", "a.c")},
+	}}.Apply(values, version.Must(version.NewVersion("0.1")), version.Must(version.NewVersion("1.0")))
+	require.Error(t, err)
+}
@@ -0,0 +1,15 @@
+package migration
+
+// Registered holds values migrations of platform versions. Add a migration here when a platform version
+// renames, moves or drops registry values keys, for example:
+//
+//	var Registered = Migrations{
+//		{
+//			Version: version.Must(version.NewVersion("1.9.7")),
+//			Steps: []Step{
+//				Rename("global.registry.bpms.istio", "serviceMesh"),
+//				Default("global.excludePortals", []any{}),
+//			},
+//		},
+//	}
+var Registered Migrations
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/tracing"
 
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
@@ -25,6 +26,8 @@ func Make(restConfig *rest.Config, k8sService k8s.ServiceInterface) (*Service, e
 	}
 
 	svc.restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	// resty configures tls only on plain http transport, so it is wrapped after
+	svc.restyClient.SetTransport(tracing.Transport(svc.restyClient.GetClient().Transport))
 
 	return &svc, nil
 }
@@ -36,6 +39,7 @@ type Metadata struct {
 type User struct {
 	Metadata Metadata `json:"metadata"`
 	FullName string   `json:"fullName"`
+	Groups   []string `json:"groups"`
 }
 
 type Status struct {
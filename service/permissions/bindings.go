@@ -0,0 +1,114 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"ddm-admin-console/service/k8s"
+)
+
+const authenticatedGroup = "system:authenticated"
+
+// BindingRules are role bindings of namespace together with rules of roles they reference,
+// cluster roles which can not be read are kept without rules
+type BindingRules struct {
+	Bindings []rbacv1.RoleBinding
+	Rules    map[string]Rules
+}
+
+func LoadBindingRules(ctx context.Context, k8sService k8s.ServiceInterface) (*BindingRules, error) {
+	roles, err := k8sService.GetRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get roles: %w", err)
+	}
+
+	bindings, err := k8sService.GetRoleBindings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get role bindings: %w", err)
+	}
+
+	br := BindingRules{Bindings: bindings, Rules: make(map[string]Rules)}
+	for _, r := range roles {
+		br.Rules[roleKey("Role", r.Name)] = PolicyRules(r.Rules)
+	}
+
+	for _, b := range bindings {
+		key := roleKey(b.RoleRef.Kind, b.RoleRef.Name)
+		if _, ok := br.Rules[key]; ok || b.RoleRef.Kind != "ClusterRole" {
+			continue
+		}
+
+		cr, err := k8sService.GetClusterRole(ctx, b.RoleRef.Name)
+		if err != nil {
+			br.Rules[key] = nil
+			continue
+		}
+
+		br.Rules[key] = PolicyRules(cr.Rules)
+	}
+
+	return &br, nil
+}
+
+// RoleRules returns rules of role referenced by binding
+func (b *BindingRules) RoleRules(ref rbacv1.RoleRef) Rules {
+	return b.Rules[roleKey(ref.Kind, ref.Name)]
+}
+
+// GrantedBy returns role bindings of user or its groups which allow verb on named object
+func (b *BindingRules) GrantedBy(user string, groups []string, group, resource, verb, name string) []rbacv1.RoleBinding {
+	var granted []rbacv1.RoleBinding
+	for _, rb := range b.Bindings {
+		if !bindsUser(rb.Subjects, user, groups) {
+			continue
+		}
+
+		if b.RoleRules(rb.RoleRef).Allows(group, resource, verb, name) {
+			granted = append(granted, rb)
+		}
+	}
+
+	return granted
+}
+
+func PolicyRules(rules []rbacv1.PolicyRule) Rules {
+	converted := make(Rules, 0, len(rules))
+	for _, r := range rules {
+		converted = append(converted, authorizationv1.ResourceRule{
+			Verbs:         r.Verbs,
+			APIGroups:     r.APIGroups,
+			Resources:     r.Resources,
+			ResourceNames: r.ResourceNames,
+		})
+	}
+
+	return converted
+}
+
+func bindsUser(subjects []rbacv1.Subject, user string, groups []string) bool {
+	for _, s := range subjects {
+		switch s.Kind {
+		case rbacv1.UserKind:
+			if s.Name == user {
+				return true
+			}
+		case rbacv1.GroupKind:
+			if s.Name == authenticatedGroup || contains(groups, s.Name) {
+				return true
+			}
+		case rbacv1.ServiceAccountKind:
+			if fmt.Sprintf("system:serviceaccount:%s:%s", s.Namespace, s.Name) == user {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func roleKey(kind, name string) string {
+	return kind + "/" + name
+}
@@ -8,11 +8,11 @@ import (
 )
 
 type ServiceInterface interface {
-	SetPermission(token string, registryName string, permission RegistryPermission)
-	GetPermission(token, registryName string) (*RegistryPermission, error)
 	DeleteToken(tok string)
 	DeleteRegistry(name string)
+	ExplainRegistries(ctx *gin.Context, names []string) (map[string]RegistryDecision, error)
 	FilterCodebases(ginContext *gin.Context, cbs []codebase.Codebase, k8sService k8s.ServiceInterface) ([]codebase.WithPermissions, error)
-	LoadUserRegistries(ctx *gin.Context) error
+	HasRegistryAccess(ctx *gin.Context) (bool, error)
+	Invalidate()
 	DeleteTokenContext(ctx *gin.Context) error
 }
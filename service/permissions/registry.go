@@ -4,7 +4,6 @@ import (
 	"ddm-admin-console/router"
 	"ddm-admin-console/service/codebase"
 	"ddm-admin-console/service/k8s"
-	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,29 +11,60 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// RulesTTL limits how long rules of user are trusted, RBAC changes which are not watched are picked up after it
+const RulesTTL = 5 * time.Minute
+
+const (
+	SourceRulesReview  = "rules-review"
+	SourceAccessReview = "access-review"
+)
+
 type RegistryPermission struct {
 	CanGet    bool
 	CanUpdate bool
 	CanDelete bool
-	Expiry    time.Time
+}
+
+func (p RegistryPermission) all() bool {
+	return p.CanGet && p.CanUpdate && p.CanDelete
+}
+
+// RegistryDecision is registry permission console uses together with its source, permissions which
+// incomplete rules review could not grant are taken from access reviews
+type RegistryDecision struct {
+	RegistryPermission
+	Source string
+}
+
+type userPermissions struct {
+	rules      Rules
+	incomplete bool
+	k8sService k8s.ServiceInterface
+	// access reviews results of registries which rules could not grant because rules review was incomplete
+	reviewed map[string]RegistryPermission
+	expiry   time.Time
 }
 
 type Registry struct {
-	//token - []permission
-	perms           map[string]map[string]RegistryPermission
-	permsLock       sync.RWMutex
+	//token - permissions
+	perms      map[string]*userPermissions
+	permsLock  sync.RWMutex
+	generation uint64
+
 	codebaseService codebase.ServiceInterface
 	k8sService      k8s.ServiceInterface
+	ttl             time.Duration
 }
 
 func Make(cbService codebase.ServiceInterface, k8sService k8s.ServiceInterface) *Registry {
 	r := Registry{
-		perms:           make(map[string]map[string]RegistryPermission),
+		perms:           make(map[string]*userPermissions),
 		k8sService:      k8sService,
 		codebaseService: cbService,
+		ttl:             RulesTTL,
 	}
 
-	//go r.expiryTicker()
+	go r.expiryTicker()
 
 	return &r
 }
@@ -48,68 +78,25 @@ func (r *Registry) expiryTicker() {
 }
 
 func (r *Registry) CheckExpiry() {
-	r.permsLock.RLock()
+	now := time.Now()
 
-	var tokensToRemove []string
-	for tok, regPerms := range r.perms {
-		for _, perms := range regPerms {
-			if time.Now().Unix() > perms.Expiry.Unix() {
-				tokensToRemove = append(tokensToRemove, tok)
-			}
+	r.permsLock.Lock()
+	defer r.permsLock.Unlock()
 
-			break
+	for tok, p := range r.perms {
+		if now.After(p.expiry) {
+			delete(r.perms, tok)
 		}
 	}
-
-	r.permsLock.RUnlock()
-
-	r.permsLock.Lock()
-	for _, t := range tokensToRemove {
-		delete(r.perms, t)
-	}
-	r.permsLock.Unlock()
 }
 
-func (r *Registry) SetPermission(token string, registryName string, permission RegistryPermission) {
-	if time.Now().Unix() > permission.Expiry.Unix() {
-		return
-	}
-
+// Invalidate drops permissions of all users, they are loaded again on next check
+func (r *Registry) Invalidate() {
 	r.permsLock.Lock()
-	tokenPerms, ok := r.perms[token]
-	if !ok {
-		tokenPerms = make(map[string]RegistryPermission)
-	}
-
-	tokenPerms[registryName] = permission
-
-	r.perms[token] = tokenPerms
-	r.permsLock.Unlock()
-}
-
-func (r *Registry) GetPermission(token, registryName string) (*RegistryPermission, error) {
-	r.permsLock.RLock()
-
-	regs, ok := r.perms[token]
-	if !ok {
-		r.permsLock.RUnlock()
-		return nil, errors.New("no permission")
-	}
-
-	perm, ok := regs[registryName]
-	if !ok {
-		r.permsLock.RUnlock()
-		return nil, errors.New("no permission")
-	}
-
-	if time.Now().Unix() > perm.Expiry.Unix() {
-		r.permsLock.RUnlock()
-		r.DeleteToken(token)
-		return nil, errors.New("no permission")
-	}
+	defer r.permsLock.Unlock()
 
-	r.permsLock.RUnlock()
-	return &perm, nil
+	r.perms = make(map[string]*userPermissions)
+	r.generation++
 }
 
 func (r *Registry) DeleteTokenContext(ctx *gin.Context) error {
@@ -134,40 +121,26 @@ func (r *Registry) DeleteRegistry(name string) {
 	r.permsLock.Lock()
 	defer r.permsLock.Unlock()
 
-	for token, regPerms := range r.perms {
-		_, ok := regPerms[name]
-		if ok {
-			delete(r.perms[token], name)
-			return
-		}
+	for _, p := range r.perms {
+		delete(p.reviewed, name)
 	}
 }
 
 func (r *Registry) FilterCodebases(ginContext *gin.Context, cbs []codebase.Codebase, k8sService k8s.ServiceInterface) ([]codebase.WithPermissions, error) {
-	tok, err := router.ExtractToken(ginContext)
+	p, err := r.userPermissions(ginContext, k8sService)
 	if err != nil {
-		return nil, fmt.Errorf("no token: %w", err)
+		return nil, fmt.Errorf("unable to load user permissions: %w", err)
 	}
 
 	withPerms := make([]codebase.WithPermissions, 0, len(cbs))
-	for i, cb := range cbs {
-		perm, err := r.GetPermission(tok.AccessToken, cb.Name)
-		if err == nil {
-			if !perm.CanGet {
-				continue
-			}
-		} else {
-			canGet, canUpdate, canDelete, err := codebase.CheckCodebasePermission(cb.Name, k8sService)
-			if err != nil {
-				return nil, fmt.Errorf("unable to check perms: %w", err)
-			}
-
-			perm = &RegistryPermission{CanUpdate: canUpdate, CanDelete: canDelete, CanGet: canGet, Expiry: tok.Expiry}
-			r.SetPermission(tok.AccessToken, cb.Name, *perm)
-
-			if !canGet {
-				continue
-			}
+	for i := range cbs {
+		perm, err := r.registryPermission(p, cbs[i].Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check perms: %w", err)
+		}
+
+		if !perm.CanGet {
+			continue
 		}
 
 		withPerms = append(withPerms, codebase.WithPermissions{
@@ -180,38 +153,132 @@ func (r *Registry) FilterCodebases(ginContext *gin.Context, cbs []codebase.Codeb
 	return withPerms, nil
 }
 
-func (r *Registry) LoadUserRegistries(ctx *gin.Context) error {
-	cbs, err := r.codebaseService.GetAllByType(codebase.RegistryCodebaseType)
+// ExplainRegistries returns permissions console uses for named registries of current user
+func (r *Registry) ExplainRegistries(ctx *gin.Context, names []string) (map[string]RegistryDecision, error) {
+	p, err := r.userPermissions(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("unable to load codebases: %w", err)
+		return nil, fmt.Errorf("unable to load user permissions: %w", err)
+	}
+
+	decisions := make(map[string]RegistryDecision, len(names))
+	for _, name := range names {
+		perm, err := r.registryPermission(p, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check perms: %w", err)
+		}
+
+		source := SourceRulesReview
+		if p.incomplete && !p.rules.codebasePermission(name).all() {
+			source = SourceAccessReview
+		}
+
+		decisions[name] = RegistryDecision{RegistryPermission: perm, Source: source}
 	}
 
-	userCtx := router.ContextWithUserAccessToken(ctx)
+	return decisions, nil
+}
 
-	k8sService, err := r.k8sService.ServiceForContext(userCtx)
+// HasRegistryAccess reports whether user can view at least one registry
+func (r *Registry) HasRegistryAccess(ctx *gin.Context) (bool, error) {
+	cbs, err := r.codebaseService.GetAllByType(codebase.RegistryCodebaseType)
 	if err != nil {
-		return fmt.Errorf("unable to get k8s service for context: %w", err)
+		return false, fmt.Errorf("unable to load codebases: %w", err)
 	}
 
-	tokenData, err := router.ExtractToken(ctx)
+	p, err := r.userPermissions(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("unable to extract token from context, err: %w", err)
+		return false, fmt.Errorf("unable to load user permissions: %w", err)
 	}
 
-	for _, cb := range cbs {
-		canGet, canUpdate, canDelete, err := codebase.CheckCodebasePermission(cb.Name, k8sService)
+	for i := range cbs {
+		perm, err := r.registryPermission(p, cbs[i].Name)
 		if err != nil {
-			return fmt.Errorf("unable to check codebase permissions: %w", err)
+			return false, fmt.Errorf("unable to check perms: %w", err)
 		}
 
-		r.SetPermission(tokenData.AccessToken, cb.Name, RegistryPermission{
-			CanUpdate: canUpdate,
-			CanGet:    canGet,
-			CanDelete: canDelete,
-			Expiry:    tokenData.Expiry,
-		})
+		if perm.CanGet {
+			return true, nil
+		}
+	}
 
+	return false, nil
+}
+
+// userPermissions returns cached rules of user or requests them with single rules review
+func (r *Registry) userPermissions(ctx *gin.Context, k8sService k8s.ServiceInterface) (*userPermissions, error) {
+	tok, err := router.ExtractToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no token: %w", err)
 	}
 
-	return nil
+	r.permsLock.RLock()
+	p, ok := r.perms[tok.AccessToken]
+	generation := r.generation
+	r.permsLock.RUnlock()
+
+	if ok && time.Now().Before(p.expiry) {
+		return p, nil
+	}
+
+	if k8sService == nil {
+		k8sService, err = r.k8sService.ServiceForContext(router.ContextWithUserAccessToken(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("unable to get k8s service for context: %w", err)
+		}
+	}
+
+	rules, incomplete, err := k8sService.ResourceRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to review user rules: %w", err)
+	}
+
+	expiry := time.Now().Add(r.ttl)
+	if !tok.Expiry.IsZero() && tok.Expiry.Before(expiry) {
+		expiry = tok.Expiry
+	}
+
+	p = &userPermissions{
+		rules:      rules,
+		incomplete: incomplete,
+		k8sService: k8sService,
+		reviewed:   make(map[string]RegistryPermission),
+		expiry:     expiry,
+	}
+
+	r.permsLock.Lock()
+	// rules requested before invalidation may be outdated, they are used once but not cached
+	if r.generation == generation {
+		r.perms[tok.AccessToken] = p
+	}
+	r.permsLock.Unlock()
+
+	return p, nil
+}
+
+func (r *Registry) registryPermission(p *userPermissions, name string) (RegistryPermission, error) {
+	perm := p.rules.codebasePermission(name)
+	if !p.incomplete || perm.all() {
+		return perm, nil
+	}
+
+	r.permsLock.RLock()
+	reviewed, ok := p.reviewed[name]
+	r.permsLock.RUnlock()
+
+	if ok {
+		return reviewed, nil
+	}
+
+	canGet, canUpdate, canDelete, err := codebase.CheckCodebasePermission(name, p.k8sService)
+	if err != nil {
+		return RegistryPermission{}, fmt.Errorf("unable to check codebase permissions: %w", err)
+	}
+
+	perm = RegistryPermission{CanGet: canGet, CanUpdate: canUpdate, CanDelete: canDelete}
+
+	r.permsLock.Lock()
+	p.reviewed[name] = perm
+	r.permsLock.Unlock()
+
+	return perm, nil
 }
@@ -0,0 +1,54 @@
+package permissions
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+const (
+	codebaseGroup    = "v2.edp.epam.com"
+	codebaseResource = "codebases"
+	anyValue         = "*"
+)
+
+// Rules are resource rules of user returned by rules review, they are evaluated locally
+// instead of asking api server about every object
+type Rules []authorizationv1.ResourceRule
+
+// Allows reports whether any rule permits verb on named object, rule without resource names matches any object
+func (r Rules) Allows(group, resource, verb, name string) bool {
+	for _, rule := range r {
+		if matchValue(rule.APIGroups, group) && matchValue(rule.Resources, resource) &&
+			matchValue(rule.Verbs, verb) && (len(rule.ResourceNames) == 0 || contains(rule.ResourceNames, name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// codebasePermission evaluates registry permission, update and delete are only meaningful for visible registries
+func (r Rules) codebasePermission(name string) RegistryPermission {
+	if !r.Allows(codebaseGroup, codebaseResource, "get", name) {
+		return RegistryPermission{}
+	}
+
+	return RegistryPermission{
+		CanGet:    true,
+		CanUpdate: r.Allows(codebaseGroup, codebaseResource, "update", name),
+		CanDelete: r.Allows(codebaseGroup, codebaseResource, "delete", name),
+	}
+}
+
+func matchValue(values []string, val string) bool {
+	return contains(values, anyValue) || contains(values, val)
+}
+
+func contains(values []string, val string) bool {
+	for _, v := range values {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	k8sMock "ddm-admin-console/mocks/k8s"
+)
+
+func TestRules_Allows(t *testing.T) {
+	t.Parallel()
+
+	rules := Rules{
+		{APIGroups: []string{codebaseGroup}, Resources: []string{codebaseResource}, Verbs: []string{"get"}},
+		{APIGroups: []string{"*"}, Resources: []string{codebaseResource}, Verbs: []string{"*"},
+			ResourceNames: []string{"reg-1"}},
+	}
+
+	require.True(t, rules.Allows(codebaseGroup, codebaseResource, "get", "reg-2"))
+	require.False(t, rules.Allows(codebaseGroup, codebaseResource, "update", "reg-2"))
+	require.True(t, rules.Allows(codebaseGroup, codebaseResource, "delete", "reg-1"))
+	require.False(t, rules.Allows(codebaseGroup, "codebasebranches", "get", "reg-1"))
+
+	require.Equal(t, RegistryPermission{CanGet: true, CanUpdate: true, CanDelete: true},
+		rules.codebasePermission("reg-1"))
+	require.Equal(t, RegistryPermission{CanGet: true}, rules.codebasePermission("reg-2"))
+	require.Equal(t, RegistryPermission{}, Rules{}.codebasePermission("reg-1"))
+}
+
+func TestRegistry_registryPermissionIncomplete(t *testing.T) {
+	t.Parallel()
+
+	k8sService := k8sMock.NewServiceInterface(t)
+	k8sService.On("CanI", codebaseGroup, codebaseResource, "get", "reg").Return(true, nil).Once()
+	k8sService.On("CanI", codebaseGroup, codebaseResource, "update", "reg").Return(true, nil).Once()
+	k8sService.On("CanI", codebaseGroup, codebaseResource, "delete", "reg").Return(false, nil).Once()
+
+	r := Registry{perms: make(map[string]*userPermissions)}
+	p := userPermissions{
+		rules: Rules{{APIGroups: []string{codebaseGroup}, Resources: []string{codebaseResource},
+			Verbs: []string{"get"}}},
+		incomplete: true,
+		k8sService: k8sService,
+		reviewed:   make(map[string]RegistryPermission),
+	}
+
+	for i := 0; i < 2; i++ {
+		perm, err := r.registryPermission(&p, "reg")
+		require.NoError(t, err)
+		require.Equal(t, RegistryPermission{CanGet: true, CanUpdate: true}, perm)
+	}
+
+	r.perms["token"] = &p
+	r.DeleteRegistry("reg")
+	require.Empty(t, p.reviewed)
+
+	r.Invalidate()
+	require.Empty(t, r.perms)
+	require.Equal(t, uint64(1), r.generation)
+}
@@ -0,0 +1,7 @@
+package roles
+
+import "context"
+
+type ServiceInterface interface {
+	UserRoles(ctx context.Context, groups []string) ([]string, error)
+}
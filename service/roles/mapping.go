@@ -0,0 +1,50 @@
+package roles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mapping maps openshift groups to console roles, it is stored in config map
+// where each key is a role name and value is a comma or new line separated list of groups
+type Mapping map[string][]string
+
+func ParseMapping(data map[string]string) (Mapping, error) {
+	mapping := make(Mapping, len(data))
+	for role, groups := range data {
+		if !IsKnownRole(role) {
+			return nil, fmt.Errorf("unknown console role: %s", role)
+		}
+
+		for _, g := range strings.FieldsFunc(groups, func(r rune) bool { return r == ',' || r == '\n' }) {
+			if g = strings.TrimSpace(g); g != "" {
+				mapping[role] = append(mapping[role], g)
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+// Roles returns sorted roles of user which is a member of groups
+func (m Mapping) Roles(groups []string) []string {
+	member := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		member[g] = struct{}{}
+	}
+
+	roles := make([]string, 0)
+	for role, roleGroups := range m {
+		for _, g := range roleGroups {
+			if _, ok := member[g]; ok {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+
+	sort.Strings(roles)
+
+	return roles
+}
@@ -0,0 +1,104 @@
+package roles
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ddm-admin-console/router"
+)
+
+// RoutePermissions lists console permissions required by routes, key is request method and route path,
+// routes which are not listed require only view permission when they are under /admin/
+var RoutePermissions = map[string]Permission{
+	"GET /admin/registry/create":                        PermissionCreateRegistry,
+	"POST /admin/registry/create":                       PermissionCreateRegistry,
+	"GET /admin/registry/edit/:name":                    PermissionEditRegistry,
+	"POST /admin/registry/edit/:name":                   PermissionEditRegistry,
+	"POST /admin/registry/overview":                     PermissionDeleteRegistry,
+	"POST /admin/registry/restore/:name":                PermissionDeleteRegistry,
+	"POST /admin/registry/officer-create/:name":         PermissionEditRegistry,
+	"POST /admin/registry/officer-enabled/:name":        PermissionEditRegistry,
+	"POST /admin/registry/officer-access/:name":         PermissionEditRegistry,
+	"POST /admin/registry/officer-import/:name":         PermissionEditRegistry,
+	"POST /admin/registry/admin-reset-password/:name":   PermissionManageAdmins,
+	"POST /admin/registry/admin-enabled/:name":          PermissionManageAdmins,
+	"POST /admin/registry/admin-expiry/:name":           PermissionManageAdmins,
+	"POST /admin/registry/access-grant/:name":           PermissionManageAccess,
+	"POST /admin/registry/access-revoke/:name":          PermissionManageAccess,
+	"GET /admin/registry/update/:name":                  PermissionEditRegistry,
+	"POST /admin/registry/update/:name":                 PermissionEditRegistry,
+	"POST /admin/registry/trembita-client/:name":        PermissionEditRegistry,
+	"POST /admin/registry/trembita-client-create/:name": PermissionEditRegistry,
+	"POST /admin/registry/trembita-client-delete/:name": PermissionEditRegistry,
+	"POST /admin/registry/external-system/:name":        PermissionEditRegistry,
+	"POST /admin/registry/external-system-create/:name": PermissionEditRegistry,
+	"POST /admin/registry/external-system-delete/:name": PermissionEditRegistry,
+	"POST /admin/registry/external-reg-add/:name":       PermissionEditRegistry,
+	"POST /admin/registry/external-reg-remove/:name":    PermissionEditRegistry,
+	"POST /admin/registry/external-reg-disable/:name":   PermissionEditRegistry,
+	"POST /admin/registry/public-api-add/:name":         PermissionEditRegistry,
+	"POST /admin/registry/public-api-edit/:name":        PermissionEditRegistry,
+	"POST /admin/registry/public-api-delete/:name":      PermissionEditRegistry,
+	"POST /admin/registry/public-api-disable/:name":     PermissionEditRegistry,
+	"POST /admin/submit-change/:change":                 PermissionSubmitChange,
+	"POST /admin/abandon-change/:change":                PermissionSubmitChange,
+
+	"GET /admin/cluster/edit":                  PermissionEditCluster,
+	"POST /admin/cluster/edit":                 PermissionEditCluster,
+	"POST /admin/cluster/upgrade":              PermissionEditCluster,
+	"POST /admin/cluster/admins":               PermissionManageAdmins,
+	"POST /admin/cluster/admin-reset-password": PermissionManageAdmins,
+	"POST /admin/cluster/admin-enabled":        PermissionManageAdmins,
+	"POST /admin/cluster/admin-expiry":         PermissionManageAdmins,
+	"POST /admin/cluster/key":                  PermissionEditKeys,
+	"POST /admin/cluster/upload-pem-dns":       PermissionEditKeys,
+	"POST /admin/cluster/cidr":                 PermissionEditCIDR,
+	"POST /admin/cluster/demo-registry-name":   PermissionEditCluster,
+	"POST /admin/cluster/general":              PermissionEditCluster,
+	"POST /admin/cluster/backup-schedule":      PermissionEditCluster,
+	"POST /admin/cluster/add-keycloak-dns":     PermissionEditCluster,
+}
+
+// publicAdminRoutes are available to authenticated users without console roles
+var publicAdminRoutes = map[string]struct{}{
+	"GET /admin/logout":                    {},
+	"GET /admin/access-diagnostics":        {},
+	"GET /admin/access-diagnostics/report": {},
+	"GET /admin/sessions":                  {},
+	"GET /admin/sessions/list":             {},
+	"POST /admin/sessions/revoke/:key":     {},
+}
+
+// RequiredPermission returns permission required by route, false is returned for routes without restrictions
+func RequiredPermission(method, fullPath string) (Permission, bool) {
+	key := method + " " + fullPath
+	if p, ok := RoutePermissions[key]; ok {
+		return p, true
+	}
+
+	if _, ok := publicAdminRoutes[key]; ok || !strings.HasPrefix(fullPath, "/admin/") {
+		return "", false
+	}
+
+	return PermissionView, true
+}
+
+// Middleware rejects requests of users whose console roles do not grant permission required by route,
+// roles are resolved on login and kept in session
+func Middleware(ctx *gin.Context) {
+	permission, ok := RequiredPermission(ctx.Request.Method, ctx.FullPath())
+	if !ok || !ctx.GetBool(router.AuthTokenValidSessionKey) {
+		ctx.Next()
+		return
+	}
+
+	if !Allowed(ctx.GetStringSlice(router.RolesSessionKey), permission) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "console role does not permit this action",
+			"permission": permission})
+		return
+	}
+
+	ctx.Next()
+}
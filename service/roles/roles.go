@@ -0,0 +1,78 @@
+package roles
+
+import "sort"
+
+// Permission is a console action which may be granted to user by console role,
+// kubernetes RBAC on codebases is still checked by actions themselves
+type Permission string
+
+const (
+	PermissionView           Permission = "view"
+	PermissionCreateRegistry Permission = "create-registry"
+	PermissionEditRegistry   Permission = "edit-registry"
+	PermissionDeleteRegistry Permission = "delete-registry"
+	PermissionSubmitChange   Permission = "submit-change"
+	PermissionEditKeys       Permission = "edit-keys"
+	PermissionEditCIDR       Permission = "edit-cidr"
+	PermissionManageAdmins   Permission = "manage-admins"
+	PermissionManageAccess   Permission = "manage-access"
+	PermissionEditCluster    Permission = "edit-cluster"
+	PermissionDiagnoseUsers  Permission = "diagnose-users"
+	PermissionManageSessions Permission = "manage-sessions"
+)
+
+const (
+	RoleViewer          = "viewer"
+	RoleEditor          = "editor"
+	RoleApprover        = "approver"
+	RoleSecurityOfficer = "security-officer"
+	RolePlatformAdmin   = "platform-admin"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleViewer:   {PermissionView},
+	RoleEditor:   {PermissionView, PermissionCreateRegistry, PermissionEditRegistry},
+	RoleApprover: {PermissionView, PermissionSubmitChange},
+	RoleSecurityOfficer: {PermissionView, PermissionEditKeys, PermissionEditCIDR,
+		PermissionManageAdmins, PermissionManageAccess, PermissionManageSessions},
+	RolePlatformAdmin: {PermissionView, PermissionCreateRegistry, PermissionEditRegistry, PermissionDeleteRegistry,
+		PermissionSubmitChange, PermissionEditKeys, PermissionEditCIDR, PermissionManageAdmins, PermissionManageAccess,
+		PermissionEditCluster, PermissionDiagnoseUsers, PermissionManageSessions},
+}
+
+func IsKnownRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Allowed reports whether any of roles grants permission
+func Allowed(roles []string, permission Permission) bool {
+	for _, r := range roles {
+		for _, p := range rolePermissions[r] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Permissions returns sorted distinct permissions granted by roles
+func Permissions(roles []string) []Permission {
+	set := make(map[Permission]struct{})
+	for _, r := range roles {
+		for _, p := range rolePermissions[r] {
+			set[p] = struct{}{}
+		}
+	}
+
+	perms := make([]Permission, 0, len(set))
+	for p := range set {
+		perms = append(perms, p)
+	}
+
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+
+	return perms
+}
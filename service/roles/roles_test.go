@@ -0,0 +1,114 @@
+package roles
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	k8sMock "ddm-admin-console/mocks/k8s"
+	"ddm-admin-console/router"
+)
+
+func TestMapping_Roles(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := ParseMapping(map[string]string{
+		RoleViewer:   "all-users",
+		RoleApprover: "approvers, leads\nreviewers",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{RoleApprover, RoleViewer}, mapping.Roles([]string{"leads", "all-users"}))
+	require.Equal(t, []string{RoleApprover}, mapping.Roles([]string{"reviewers"}))
+	require.Empty(t, mapping.Roles([]string{"others"}))
+
+	_, err = ParseMapping(map[string]string{"owner": "all-users"})
+	require.Error(t, err)
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, Allowed([]string{RoleViewer, RoleApprover}, PermissionSubmitChange))
+	require.False(t, Allowed([]string{RoleEditor}, PermissionSubmitChange))
+	require.False(t, Allowed(nil, PermissionView))
+	require.Equal(t, []Permission{PermissionEditCIDR, PermissionEditKeys, PermissionManageAccess, PermissionManageAdmins,
+		PermissionManageSessions, PermissionView},
+		Permissions([]string{RoleSecurityOfficer, RoleViewer}))
+}
+
+func TestRequiredPermission(t *testing.T) {
+	t.Parallel()
+
+	p, ok := RequiredPermission(http.MethodPost, "/admin/cluster/cidr")
+	require.True(t, ok)
+	require.Equal(t, PermissionEditCIDR, p)
+
+	p, ok = RequiredPermission(http.MethodGet, "/admin/registry/view/:name")
+	require.True(t, ok)
+	require.Equal(t, PermissionView, p)
+
+	_, ok = RequiredPermission(http.MethodGet, "/admin/logout")
+	require.False(t, ok)
+
+	_, ok = RequiredPermission(http.MethodGet, "/auth/callback")
+	require.False(t, ok)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	serve := func(userRoles []string) int {
+		engine := gin.New()
+		engine.Use(func(ctx *gin.Context) {
+			ctx.Set(router.AuthTokenValidSessionKey, true)
+			ctx.Set(router.RolesSessionKey, userRoles)
+		}, Middleware)
+		engine.POST("/admin/cluster/cidr", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/cluster/cidr", nil))
+
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve([]string{RoleSecurityOfficer}))
+	require.Equal(t, http.StatusForbidden, serve([]string{RoleEditor}))
+	require.Equal(t, http.StatusForbidden, serve(nil))
+}
+
+func TestService_UserRoles(t *testing.T) {
+	t.Parallel()
+
+	k8sService := k8sMock.NewServiceInterface(t)
+	k8sService.On("GetConfigMap", mock.Anything, "missing", "ns").
+		Return(nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "missing")).Once()
+	k8sService.On("GetConfigMap", mock.Anything, "console-roles", "ns").Return(&v1.ConfigMap{
+		Data: map[string]string{RoleEditor: "editors"},
+	}, nil).Once()
+	k8sService.On("GetConfigMap", mock.Anything, "empty-roles", "ns").Return(&v1.ConfigMap{
+		Data: map[string]string{RoleViewer: "", RoleEditor: "", RolePlatformAdmin: " \n"},
+	}, nil).Once()
+
+	userRoles, err := Make(k8sService, "missing", "ns").UserRoles(context.Background(), []string{"editors"})
+	require.NoError(t, err)
+	require.Equal(t, []string{RoleViewer}, userRoles)
+
+	userRoles, err = Make(k8sService, "console-roles", "ns").UserRoles(context.Background(), []string{"editors"})
+	require.NoError(t, err)
+	require.Equal(t, []string{RoleEditor}, userRoles)
+
+	userRoles, err = Make(k8sService, "empty-roles", "ns").UserRoles(context.Background(), []string{"editors"})
+	require.NoError(t, err)
+	require.Equal(t, []string{RoleViewer}, userRoles, "config map without groups is not configured")
+}
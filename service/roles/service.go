@@ -0,0 +1,48 @@
+package roles
+
+import (
+	"context"
+	"fmt"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"ddm-admin-console/service/k8s"
+)
+
+type Service struct {
+	k8sService    k8s.ServiceInterface
+	configMapName string
+	namespace     string
+}
+
+func Make(k8sService k8s.ServiceInterface, configMapName, namespace string) *Service {
+	return &Service{
+		k8sService:    k8sService,
+		configMapName: configMapName,
+		namespace:     namespace,
+	}
+}
+
+// UserRoles resolves console roles of user from its groups, while roles config map is not created or maps
+// no groups every user is a viewer, so a missing mapping never grants write access
+func (s *Service) UserRoles(ctx context.Context, groups []string) ([]string, error) {
+	cm, err := s.k8sService.GetConfigMap(ctx, s.configMapName, s.namespace)
+	if k8sErrors.IsNotFound(err) {
+		return []string{RoleViewer}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get roles config map, %w", err)
+	}
+
+	mapping, err := ParseMapping(cm.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse roles config map, %w", err)
+	}
+
+	if len(mapping) == 0 {
+		return []string{RoleViewer}, nil
+	}
+
+	return mapping.Roles(groups), nil
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Record is a server side session, values are gob encoded session values
+type Record struct {
+	ID        string
+	User      string
+	CreatedAt time.Time
+	LastSeen  time.Time
+	Values    []byte
+}
+
+// Backend keeps session records, Get returns nil record when session does not exist
+type Backend interface {
+	Get(ctx context.Context, id string) (*Record, error)
+	Put(ctx context.Context, rec *Record) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]Record, error)
+}
+
+// MemoryBackend keeps sessions in process memory, sessions are lost on restart and are not shared between replicas
+type MemoryBackend struct {
+	records map[string]Record
+	lock    sync.RWMutex
+}
+
+func MakeMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{records: make(map[string]Record)}
+}
+
+func (m *MemoryBackend) Get(_ context.Context, id string) (*Record, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	rec, ok := m.records[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rec, nil
+}
+
+func (m *MemoryBackend) Put(_ context.Context, rec *Record) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.records[rec.ID] = *rec
+
+	return nil
+}
+
+func (m *MemoryBackend) Delete(_ context.Context, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.records, id)
+
+	return nil
+}
+
+func (m *MemoryBackend) List(_ context.Context) ([]Record, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	records := make([]Record, 0, len(m.records))
+	for _, rec := range m.records {
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
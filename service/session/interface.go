@@ -0,0 +1,13 @@
+package session
+
+import (
+	"context"
+
+	"github.com/gin-contrib/sessions"
+)
+
+type ServiceInterface interface {
+	sessions.Store
+	List(ctx context.Context) ([]Info, error)
+	Revoke(ctx context.Context, key string) error
+}
@@ -0,0 +1,167 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ddm-admin-console/service/k8s"
+)
+
+const (
+	secretLabel      = "console/session"
+	secretNamePrefix = "console-session-"
+
+	secretKeyUser      = "user"
+	secretKeyCreatedAt = "created-at"
+	secretKeyLastSeen  = "last-seen"
+	secretKeyValues    = "values"
+
+	// SecretCacheTTL limits how long session read from secret is trusted, sessions revoked by other
+	// console replicas stop working after it
+	SecretCacheTTL = 10 * time.Second
+)
+
+type cachedRecord struct {
+	rec      Record
+	loadedAt time.Time
+}
+
+// SecretBackend keeps every session in separate kubernetes secret of console namespace,
+// so sessions survive restarts and are shared between console replicas
+type SecretBackend struct {
+	k8sService k8s.ServiceInterface
+	namespace  string
+	cache      map[string]cachedRecord
+	cacheLock  sync.Mutex
+}
+
+func MakeSecretBackend(k8sService k8s.ServiceInterface, namespace string) *SecretBackend {
+	return &SecretBackend{
+		k8sService: k8sService,
+		namespace:  namespace,
+		cache:      make(map[string]cachedRecord),
+	}
+}
+
+func (b *SecretBackend) Get(ctx context.Context, id string) (*Record, error) {
+	b.cacheLock.Lock()
+	cached, ok := b.cache[id]
+	b.cacheLock.Unlock()
+
+	if ok && time.Since(cached.loadedAt) < SecretCacheTTL {
+		rec := cached.rec
+		return &rec, nil
+	}
+
+	secret, err := b.k8sService.GetSecretFromNamespace(ctx, secretNamePrefix+id, b.namespace)
+	if k8sErrors.IsNotFound(err) {
+		b.forget(id)
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get session secret, %w", err)
+	}
+
+	rec, err := secretRecord(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	b.remember(rec)
+
+	return rec, nil
+}
+
+func (b *SecretBackend) Put(ctx context.Context, rec *Record) error {
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   secretNamePrefix + rec.ID,
+			Labels: map[string]string{secretLabel: "true"},
+		},
+		Data: map[string][]byte{
+			secretKeyUser:      []byte(rec.User),
+			secretKeyCreatedAt: []byte(rec.CreatedAt.Format(time.RFC3339Nano)),
+			secretKeyLastSeen:  []byte(rec.LastSeen.Format(time.RFC3339Nano)),
+			secretKeyValues:    rec.Values,
+		},
+	}
+
+	if err := b.k8sService.ApplySecret(ctx, &secret); err != nil {
+		return fmt.Errorf("unable to save session secret, %w", err)
+	}
+
+	b.remember(rec)
+
+	return nil
+}
+
+func (b *SecretBackend) Delete(ctx context.Context, id string) error {
+	b.forget(id)
+
+	if err := b.k8sService.DeleteSecret(ctx, secretNamePrefix+id); err != nil {
+		return fmt.Errorf("unable to delete session secret, %w", err)
+	}
+
+	return nil
+}
+
+func (b *SecretBackend) List(ctx context.Context) ([]Record, error) {
+	secrets, err := b.k8sService.GetSecrets(ctx, secretLabel+"=true")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list session secrets, %w", err)
+	}
+
+	records := make([]Record, 0, len(secrets))
+	for i := range secrets {
+		rec, err := secretRecord(&secrets[i])
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, *rec)
+	}
+
+	return records, nil
+}
+
+func (b *SecretBackend) remember(rec *Record) {
+	b.cacheLock.Lock()
+	defer b.cacheLock.Unlock()
+
+	b.cache[rec.ID] = cachedRecord{rec: *rec, loadedAt: time.Now()}
+}
+
+func (b *SecretBackend) forget(id string) {
+	b.cacheLock.Lock()
+	defer b.cacheLock.Unlock()
+
+	delete(b.cache, id)
+}
+
+func secretRecord(secret *v1.Secret) (*Record, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, string(secret.Data[secretKeyCreatedAt]))
+	if err != nil {
+		return nil, fmt.Errorf("wrong session creation time in secret %s, %w", secret.Name, err)
+	}
+
+	lastSeen, err := time.Parse(time.RFC3339Nano, string(secret.Data[secretKeyLastSeen]))
+	if err != nil {
+		return nil, fmt.Errorf("wrong session last seen time in secret %s, %w", secret.Name, err)
+	}
+
+	return &Record{
+		ID:        strings.TrimPrefix(secret.Name, secretNamePrefix),
+		User:      string(secret.Data[secretKeyUser]),
+		CreatedAt: createdAt,
+		LastSeen:  lastSeen,
+		Values:    secret.Data[secretKeyValues],
+	}, nil
+}
@@ -0,0 +1,345 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+)
+
+const (
+	idLength  = 32
+	keyLength = 16
+
+	maxTouchInterval = time.Minute
+	cleanupInterval  = 10 * time.Minute
+)
+
+var ErrRevoked = errors.New("session is revoked or expired")
+
+type Config struct {
+	IdleTimeout     time.Duration
+	AbsoluteTimeout time.Duration
+	// UserKey is a session value which names user in sessions list
+	UserKey string
+	Secure  bool
+}
+
+// Info describes active session without exposing its id, key identifies session for revocation
+type Info struct {
+	Key       string    `json:"key"`
+	User      string    `json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+	LastSeen  time.Time `json:"lastSeen"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Store keeps session values in backend, cookie holds only signed session id
+type Store struct {
+	backend Backend
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+	config  Config
+}
+
+func Make(backend Backend, secret []byte, cnf Config) *Store {
+	s := Store{
+		backend: backend,
+		codecs:  securecookie.CodecsFromPairs(secret),
+		config:  cnf,
+	}
+
+	s.Options(sessions.Options{
+		Path:     "/",
+		MaxAge:   int(cnf.AbsoluteTimeout.Seconds()),
+		Secure:   cnf.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	go s.cleanupTicker()
+
+	return &s
+}
+
+func (s *Store) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+
+	for _, c := range s.codecs {
+		if sc, ok := c.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(options.MaxAge)
+		}
+	}
+}
+
+func (s *Store) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads session of request cookie, new empty session is returned when cookie is missing, signed with other
+// secret or session is expired or revoked
+func (s *Store) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	opts := *s.options
+	session.Options = &opts
+	session.IsNew = true
+
+	c, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var id string
+	if err := securecookie.DecodeMulti(name, c.Value, &id, s.codecs...); err != nil {
+		return session, nil
+	}
+
+	rec, err := s.load(r.Context(), id)
+	if err != nil || rec == nil {
+		return session, err
+	}
+
+	if err := (securecookie.GobEncoder{}).Deserialize(rec.Values, &session.Values); err != nil {
+		return session, fmt.Errorf("unable to decode session values, %w", err)
+	}
+
+	session.ID = id
+	session.IsNew = false
+
+	return session, nil
+}
+
+// Save stores session values and sets cookie with session id, session without values is removed
+func (s *Store) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	ctx := r.Context()
+
+	if session.Options.MaxAge < 0 || len(session.Values) == 0 {
+		if session.ID != "" {
+			if err := s.backend.Delete(ctx, session.ID); err != nil {
+				return fmt.Errorf("unable to delete session, %w", err)
+			}
+		}
+
+		opts := *session.Options
+		opts.MaxAge = -1
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", &opts))
+
+		return nil
+	}
+
+	now := time.Now()
+	rec := Record{ID: session.ID, CreatedAt: now, LastSeen: now}
+
+	if rec.ID == "" {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+
+		rec.ID = id
+	} else {
+		current, err := s.backend.Get(ctx, rec.ID)
+		if err != nil {
+			return fmt.Errorf("unable to load session, %w", err)
+		}
+
+		// session revoked during request must not be recreated with its old values
+		if current == nil {
+			return ErrRevoked
+		}
+
+		rec.CreatedAt = current.CreatedAt
+	}
+
+	rec.User, _ = session.Values[s.config.UserKey].(string)
+
+	values, err := (securecookie.GobEncoder{}).Serialize(session.Values)
+	if err != nil {
+		return fmt.Errorf("unable to encode session values, %w", err)
+	}
+	rec.Values = values
+
+	if err := s.backend.Put(ctx, &rec); err != nil {
+		return fmt.Errorf("unable to save session, %w", err)
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), rec.ID, s.codecs...)
+	if err != nil {
+		return fmt.Errorf("unable to encode session cookie, %w", err)
+	}
+
+	session.ID = rec.ID
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+
+	return nil
+}
+
+// List returns active sessions sorted by user and last activity
+func (s *Store) List(ctx context.Context) ([]Info, error) {
+	records, err := s.backend.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list sessions, %w", err)
+	}
+
+	now := time.Now()
+	infos := make([]Info, 0, len(records))
+
+	for i := range records {
+		if s.expired(&records[i], now) {
+			continue
+		}
+
+		infos = append(infos, Info{
+			Key:       Key(records[i].ID),
+			User:      records[i].User,
+			CreatedAt: records[i].CreatedAt,
+			LastSeen:  records[i].LastSeen,
+			ExpiresAt: s.expiresAt(&records[i]),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].User != infos[j].User {
+			return infos[i].User < infos[j].User
+		}
+
+		return infos[i].LastSeen.After(infos[j].LastSeen)
+	})
+
+	return infos, nil
+}
+
+// Revoke removes session by its key, revoking unknown session is not an error
+func (s *Store) Revoke(ctx context.Context, key string) error {
+	records, err := s.backend.List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list sessions, %w", err)
+	}
+
+	for i := range records {
+		if Key(records[i].ID) == key {
+			if err := s.backend.Delete(ctx, records[i].ID); err != nil {
+				return fmt.Errorf("unable to delete session, %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Cleanup removes expired sessions which were not used after expiration
+func (s *Store) Cleanup(ctx context.Context) error {
+	records, err := s.backend.List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list sessions, %w", err)
+	}
+
+	now := time.Now()
+	for i := range records {
+		if !s.expired(&records[i], now) {
+			continue
+		}
+
+		if err := s.backend.Delete(ctx, records[i].ID); err != nil {
+			return fmt.Errorf("unable to delete session, %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) cleanupTicker() {
+	tk := time.NewTicker(cleanupInterval)
+
+	for range tk.C {
+		// failed cleanup is repeated on next tick, expired sessions are refused on load anyway
+		_ = s.Cleanup(context.Background())
+	}
+}
+
+// load returns active session record and prolongs it, expired sessions are removed
+func (s *Store) load(ctx context.Context, id string) (*Record, error) {
+	rec, err := s.backend.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load session, %w", err)
+	}
+
+	if rec == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if s.expired(rec, now) {
+		if err := s.backend.Delete(ctx, id); err != nil {
+			return nil, fmt.Errorf("unable to delete expired session, %w", err)
+		}
+
+		return nil, nil
+	}
+
+	if now.Sub(rec.LastSeen) > s.touchInterval() {
+		rec.LastSeen = now
+		if err := s.backend.Put(ctx, rec); err != nil {
+			return nil, fmt.Errorf("unable to prolong session, %w", err)
+		}
+	}
+
+	return rec, nil
+}
+
+func (s *Store) expired(rec *Record, now time.Time) bool {
+	expiresAt := s.expiresAt(rec)
+	return !expiresAt.IsZero() && now.After(expiresAt)
+}
+
+// expiresAt returns the moment session ends if it stays idle, zero time means session does not expire
+func (s *Store) expiresAt(rec *Record) time.Time {
+	var expiresAt time.Time
+	if s.config.IdleTimeout > 0 {
+		expiresAt = rec.LastSeen.Add(s.config.IdleTimeout)
+	}
+
+	if s.config.AbsoluteTimeout > 0 {
+		absolute := rec.CreatedAt.Add(s.config.AbsoluteTimeout)
+		if expiresAt.IsZero() || absolute.Before(expiresAt) {
+			expiresAt = absolute
+		}
+	}
+
+	return expiresAt
+}
+
+// touchInterval limits writes of last activity time, which are made at most once per interval
+func (s *Store) touchInterval() time.Duration {
+	if s.config.IdleTimeout > 0 && s.config.IdleTimeout/10 < maxTouchInterval {
+		return s.config.IdleTimeout / 10
+	}
+
+	return maxTouchInterval
+}
+
+// Key returns session key which identifies session in sessions list, id itself is never shown
+func Key(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:keyLength])
+}
+
+func newID() (string, error) {
+	b := make([]byte, idLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate session id, %w", err)
+	}
+
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)), nil
+}
@@ -0,0 +1,144 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gsessions "github.com/gorilla/sessions"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret = "0123456789abcdef0123456789abcdef"
+	testCookie = "console-session"
+)
+
+func saveSession(t *testing.T, s *Store, r *http.Request, values map[interface{}]interface{}) *http.Cookie {
+	t.Helper()
+
+	sess, err := s.New(r, testCookie)
+	require.NoError(t, err)
+
+	for k, v := range values {
+		sess.Values[k] = v
+	}
+
+	w := httptest.NewRecorder()
+	require.NoError(t, s.Save(r, w, sess))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	return cookies[0]
+}
+
+func requestWithCookie(c *http.Cookie) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if c != nil {
+		r.AddCookie(c)
+	}
+
+	return r
+}
+
+func loadSession(t *testing.T, s *Store, c *http.Cookie) *gsessions.Session {
+	t.Helper()
+
+	sess, err := s.New(requestWithCookie(c), testCookie)
+	require.NoError(t, err)
+
+	return sess
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	backend := MakeMemoryBackend()
+	s := Make(backend, []byte(testSecret), Config{IdleTimeout: time.Hour, AbsoluteTimeout: time.Hour, UserKey: "user"})
+
+	c := saveSession(t, s, requestWithCookie(nil), map[interface{}]interface{}{"user": "jdoe", "token": "secret-token"})
+	require.NotContains(t, c.Value, "secret-token")
+
+	sess := loadSession(t, s, c)
+	require.False(t, sess.IsNew)
+	require.Equal(t, "secret-token", sess.Values["token"])
+
+	infos, err := s.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, "jdoe", infos[0].User)
+	require.Equal(t, Key(sess.ID), infos[0].Key)
+
+	other := Make(backend, []byte("fedcba9876543210fedcba9876543210"), Config{})
+	require.True(t, loadSession(t, other, c).IsNew, "cookie signed with other secret")
+}
+
+func TestStore_Expiry(t *testing.T) {
+	t.Parallel()
+
+	backend := MakeMemoryBackend()
+	s := Make(backend, []byte(testSecret), Config{IdleTimeout: time.Minute, AbsoluteTimeout: time.Hour})
+
+	c := saveSession(t, s, requestWithCookie(nil), map[interface{}]interface{}{"token": "t"})
+	sess := loadSession(t, s, c)
+
+	rec, err := backend.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+
+	rec.LastSeen = time.Now().Add(-2 * time.Minute)
+	require.NoError(t, backend.Put(context.Background(), rec))
+	require.True(t, loadSession(t, s, c).IsNew, "idle session")
+
+	rec, err = backend.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+	require.Nil(t, rec, "expired session is removed")
+
+	c = saveSession(t, s, requestWithCookie(nil), map[interface{}]interface{}{"token": "t"})
+	sess = loadSession(t, s, c)
+
+	rec, err = backend.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+
+	rec.CreatedAt = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, backend.Put(context.Background(), rec))
+	require.True(t, loadSession(t, s, c).IsNew, "session older than absolute timeout")
+}
+
+func TestStore_Revoke(t *testing.T) {
+	t.Parallel()
+
+	s := Make(MakeMemoryBackend(), []byte(testSecret), Config{IdleTimeout: time.Hour})
+
+	c := saveSession(t, s, requestWithCookie(nil), map[interface{}]interface{}{"token": "t"})
+	r := requestWithCookie(c)
+	sess, err := s.New(r, testCookie)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Revoke(context.Background(), Key(sess.ID)))
+	require.True(t, loadSession(t, s, c).IsNew)
+
+	require.ErrorIs(t, s.Save(r, httptest.NewRecorder(), sess), ErrRevoked, "revoked session is not recreated")
+}
+
+func TestStore_SaveEmptyRemovesSession(t *testing.T) {
+	t.Parallel()
+
+	s := Make(MakeMemoryBackend(), []byte(testSecret), Config{})
+
+	c := saveSession(t, s, requestWithCookie(nil), map[interface{}]interface{}{"token": "t"})
+	r := requestWithCookie(c)
+	sess, err := s.New(r, testCookie)
+	require.NoError(t, err)
+
+	sess.Values = make(map[interface{}]interface{})
+	w := httptest.NewRecorder()
+	require.NoError(t, s.Save(r, w, sess))
+	require.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+
+	infos, err := s.List(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, infos)
+}
@@ -0,0 +1,441 @@
+package valuesdoc
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultIndent = 2
+
+// Document is a values.yaml file kept as yaml node tree, so targeted changes keep comments, key order,
+// anchors and scalar quoting of the untouched parts of the file
+type Document struct {
+	root   *yaml.Node
+	indent int
+}
+
+// Parse decodes values.yaml contents, empty contents give an empty mapping document
+func Parse(data []byte) (*Document, error) {
+	doc := Document{indent: detectIndent(data)}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("unable to decode values yaml, %w", err)
+	}
+
+	if root.Kind == 0 {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) != 1 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("values yaml root must be a mapping")
+	}
+
+	doc.root = &root
+
+	return &doc, nil
+}
+
+// Apply rewrites original values.yaml contents so that they decode to values, only the nodes that
+// differ from values are touched
+func Apply(original []byte, values map[string]any) ([]byte, error) {
+	doc, err := Parse(original)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Patch(values); err != nil {
+		return nil, err
+	}
+
+	return doc.Bytes()
+}
+
+// Decode decodes document into out the same way yaml.Unmarshal does
+func (d *Document) Decode(out any) error {
+	return d.mapping().Decode(out)
+}
+
+// Bytes encodes document back to yaml
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(d.indent)
+
+	if err := enc.Encode(d.root); err != nil {
+		return nil, fmt.Errorf("unable to encode values yaml, %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("unable to encode values yaml, %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Get returns decoded value at key path
+func (d *Document) Get(path ...string) (any, bool, error) {
+	node := d.lookup(path)
+	if node == nil {
+		return nil, false, nil
+	}
+
+	var out any
+	if err := node.Decode(&out); err != nil {
+		return nil, false, fmt.Errorf("unable to decode %s, %w", strings.Join(path, "."), err)
+	}
+
+	return out, true, nil
+}
+
+// Set sets value at key path, missing intermediate mappings are created
+func (d *Document) Set(value any, path ...string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty key path")
+	}
+
+	parent, err := d.ensureMapping(path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+
+	normalized, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	key := path[len(path)-1]
+	if idx := mappingIndex(parent, key); idx >= 0 {
+		return patchNode(parent, idx+1, normalized)
+	}
+
+	return appendKey(parent, key, normalized)
+}
+
+// Delete removes key path from document, it returns false when there was nothing to remove
+func (d *Document) Delete(path ...string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	parent := d.lookup(path[:len(path)-1])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return false
+	}
+
+	idx := mappingIndex(parent, path[len(path)-1])
+	if idx < 0 {
+		return false
+	}
+
+	parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+
+	return true
+}
+
+// Merge deep merges values into mapping at key path, keys missing in values are kept
+func (d *Document) Merge(values map[string]any, path ...string) error {
+	target, err := d.ensureMapping(path)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := normalize(values)
+	if err != nil {
+		return err
+	}
+
+	return mergeMapping(target, normalized.(map[string]any))
+}
+
+// Patch makes document decode to values, keys that are missing in values are removed
+func (d *Document) Patch(values map[string]any) error {
+	normalized, err := normalize(values)
+	if err != nil {
+		return err
+	}
+
+	return patchMapping(d.mapping(), normalized.(map[string]any))
+}
+
+func (d *Document) mapping() *yaml.Node {
+	return d.root.Content[0]
+}
+
+func (d *Document) lookup(path []string) *yaml.Node {
+	node := d.mapping()
+	for _, key := range path {
+		node = resolve(node)
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		idx := mappingIndex(node, key)
+		if idx < 0 {
+			return nil
+		}
+
+		node = node.Content[idx+1]
+	}
+
+	return resolve(node)
+}
+
+func (d *Document) ensureMapping(path []string) (*yaml.Node, error) {
+	node := d.mapping()
+	for i, key := range path {
+		idx := mappingIndex(node, key)
+		if idx < 0 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, keyNode(key), child)
+			node = child
+
+			continue
+		}
+
+		child := resolve(node.Content[idx+1])
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: child.HeadComment,
+				LineComment: child.LineComment, FootComment: child.FootComment}
+		}
+
+		if child.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a mapping", strings.Join(path[:i+1], "."))
+		}
+
+		node = child
+	}
+
+	return node, nil
+}
+
+func patchMapping(node *yaml.Node, values map[string]any) error {
+	kept := make([]*yaml.Node, 0, len(node.Content))
+	seen := make(map[string]bool, len(values))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if node.Content[i].Tag == "!!merge" {
+			kept = append(kept, node.Content[i], node.Content[i+1])
+			continue
+		}
+
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		if err := patchNode(node, i+1, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		kept = append(kept, node.Content[i], node.Content[i+1])
+		seen[key] = true
+	}
+
+	node.Content = kept
+
+	// keys inherited with "<<" merge keys are part of decoded values, but must not be written explicitly
+	inherited := make(map[string]any)
+	if err := node.Decode(&inherited); err != nil {
+		return fmt.Errorf("unable to decode mapping, %w", err)
+	}
+
+	for _, key := range sortedKeys(values) {
+		if seen[key] {
+			continue
+		}
+
+		if current, ok := inherited[key]; ok && reflect.DeepEqual(current, values[key]) {
+			continue
+		}
+
+		if err := appendKey(node, key, values[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mergeMapping(node *yaml.Node, values map[string]any) error {
+	for _, key := range sortedKeys(values) {
+		idx := mappingIndex(node, key)
+		if idx < 0 {
+			if err := appendKey(node, key, values[key]); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		current := resolve(node.Content[idx+1])
+		if sub, ok := values[key].(map[string]any); ok && current.Kind == yaml.MappingNode {
+			if err := mergeMapping(current, sub); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			continue
+		}
+
+		if err := patchNode(node, idx+1, values[key]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// patchNode updates parent.Content[idx] to hold value, node is left as is when it already decodes to value
+func patchNode(parent *yaml.Node, idx int, value any) error {
+	current := parent.Content[idx]
+
+	var decoded any
+	if err := current.Decode(&decoded); err != nil {
+		return fmt.Errorf("unable to decode node, %w", err)
+	}
+
+	if reflect.DeepEqual(decoded, value) {
+		return nil
+	}
+
+	if current.Kind != yaml.AliasNode {
+		if m, ok := value.(map[string]any); ok && current.Kind == yaml.MappingNode {
+			return patchMapping(current, m)
+		}
+
+		if s, ok := value.([]any); ok && current.Kind == yaml.SequenceNode {
+			return patchSequence(current, s)
+		}
+	}
+
+	replacement, err := valueNode(value)
+	if err != nil {
+		return err
+	}
+
+	replacement.HeadComment = current.HeadComment
+	replacement.LineComment = current.LineComment
+	replacement.FootComment = current.FootComment
+	if current.Kind != yaml.AliasNode {
+		replacement.Anchor = current.Anchor
+	}
+
+	parent.Content[idx] = replacement
+
+	return nil
+}
+
+func patchSequence(node *yaml.Node, values []any) error {
+	if len(values) < len(node.Content) {
+		node.Content = node.Content[:len(values)]
+	}
+
+	for i := range values {
+		if i < len(node.Content) {
+			if err := patchNode(node, i, values[i]); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+
+			continue
+		}
+
+		item, err := valueNode(values[i])
+		if err != nil {
+			return err
+		}
+
+		node.Content = append(node.Content, item)
+	}
+
+	return nil
+}
+
+func appendKey(node *yaml.Node, key string, value any) error {
+	vNode, err := valueNode(value)
+	if err != nil {
+		return err
+	}
+
+	node.Content = append(node.Content, keyNode(key), vNode)
+
+	return nil
+}
+
+func valueNode(value any) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, fmt.Errorf("unable to encode value, %w", err)
+	}
+
+	return &node, nil
+}
+
+func keyNode(key string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i].Tag != "!!merge" {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// normalize converts value to the types yaml decoder produces, so it can be compared with decoded nodes
+func normalize(value any) (any, error) {
+	bts, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode value, %w", err)
+	}
+
+	var out any
+	if err := yaml.Unmarshal(bts, &out); err != nil {
+		return nil, fmt.Errorf("unable to decode value, %w", err)
+	}
+
+	return out, nil
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// detectIndent returns indentation of the first nested line, so rewritten file keeps its layout
+func detectIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+
+		if indent := len(line) - len(trimmed); indent >= 2 && indent <= 8 {
+			return indent
+		}
+	}
+
+	return defaultIndent
+}
@@ -0,0 +1,131 @@
+package valuesdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testValues = `# registry values
+global:
+  # deployment mode
+  deploymentMode: development # dev only
+  registry:
+    replicas: 1
+  whiteListIP: &ips
+    - "10.0.0.1/32"
+    - 10.0.0.2/32
+keycloak:
+  realms:
+    officer:
+      browserFlow: 'dso-officer-auth-flow'
+ips: *ips
+`
+
+func decode(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+
+	out := make(map[string]any)
+	require.NoError(t, yaml.Unmarshal(data, &out))
+
+	return out
+}
+
+func TestApply_UnchangedValuesKeepFile(t *testing.T) {
+	t.Parallel()
+
+	out, err := Apply([]byte(testValues), decode(t, []byte(testValues)))
+	require.NoError(t, err)
+	require.Equal(t, testValues, string(out))
+}
+
+func TestApply_TouchesOnlyChangedNodes(t *testing.T) {
+	t.Parallel()
+
+	values := decode(t, []byte(testValues))
+	values["global"].(map[string]any)["registry"].(map[string]any)["replicas"] = 3
+	values["trembita"] = map[string]any{"enabled": true}
+	delete(values["keycloak"].(map[string]any), "realms")
+
+	out, err := Apply([]byte(testValues), values)
+	require.NoError(t, err)
+	require.Equal(t, `# registry values
+global:
+  # deployment mode
+  deploymentMode: development # dev only
+  registry:
+    replicas: 3
+  whiteListIP: &ips
+    - "10.0.0.1/32"
+    - 10.0.0.2/32
+keycloak: {}
+ips: *ips
+trembita:
+  enabled: true
+`, string(out))
+	require.Equal(t, values, decode(t, out))
+}
+
+func TestApply_Sequences(t *testing.T) {
+	t.Parallel()
+
+	values := decode(t, []byte(testValues))
+	values["global"].(map[string]any)["whiteListIP"] = []any{"10.0.0.1/32", "10.0.0.3/32", "10.0.0.4/32"}
+
+	out, err := Apply([]byte(testValues), values)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `whiteListIP: &ips
+    - "10.0.0.1/32"
+    - 10.0.0.3/32
+    - 10.0.0.4/32`)
+	require.Equal(t, values, decode(t, out))
+}
+
+func TestApply_EmptyOriginal(t *testing.T) {
+	t.Parallel()
+
+	out, err := Apply(nil, map[string]any{"global": map[string]any{"deploymentMode": "production"}})
+	require.NoError(t, err)
+	require.Equal(t, "global:\n  deploymentMode: production\n", string(out))
+}
+
+func TestDocument_SetDeleteMerge(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse([]byte(testValues))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("dso-officer-auth-flow-2", "keycloak", "realms", "officer", "browserFlow"))
+	require.NoError(t, doc.Set(map[string]any{"host": "smtp.example.com"}, "notifications", "email"))
+	require.True(t, doc.Delete("global", "deploymentMode"))
+	require.False(t, doc.Delete("global", "missing"))
+	require.NoError(t, doc.Merge(map[string]any{"registry": map[string]any{"memory": "1Gi"}}, "global"))
+
+	v, ok, err := doc.Get("global", "registry")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"replicas": 1, "memory": "1Gi"}, v)
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, `# registry values
+global:
+  registry:
+    replicas: 1
+    memory: 1Gi
+  whiteListIP: &ips
+    - "10.0.0.1/32"
+    - 10.0.0.2/32
+keycloak:
+  realms:
+    officer:
+      browserFlow: dso-officer-auth-flow-2
+ips: *ips
+notifications:
+  email:
+    host: smtp.example.com
+`, string(out))
+
+	require.Error(t, doc.Set("x", "global", "registry", "replicas", "nested"))
+}
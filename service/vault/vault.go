@@ -2,7 +2,9 @@ package vault
 
 import (
 	"context"
+	"ddm-admin-console/resilience"
 	"ddm-admin-console/service/k8s"
+	"ddm-admin-console/tracing"
 	"errors"
 	"fmt"
 	"strings"
@@ -16,6 +18,7 @@ type ServiceInterface interface {
 	WriteRaw(path string, data map[string]interface{}) (*hashiVault.Secret, error)
 	Write(path string, data map[string]interface{}) (*hashiVault.Secret, error)
 	GetPropertyFromVault(path string, property string) string
+	DeleteAll(path string) error
 }
 
 var ErrSecretIsNil = errors.New("secret is nil")
@@ -26,6 +29,7 @@ type Config struct {
 	SecretTokenKey  string
 	KVEngineName    string
 	APIAddr         string
+	ClientPolicy    resilience.Policy
 }
 
 type Service struct {
@@ -35,6 +39,8 @@ type Service struct {
 func Make(cnf Config, k8s k8s.ServiceInterface) (*Service, error) {
 	config := hashiVault.DefaultConfig()
 	config.Address = cnf.APIAddr
+	config.HttpClient.Transport = resilience.Transport("vault", cnf.ClientPolicy,
+		tracing.Transport(config.HttpClient.Transport))
 
 	client, err := hashiVault.NewClient(config)
 	if err != nil {
@@ -63,6 +69,16 @@ func ModifyVaultPath(path string) string {
 	return strings.Join(pathParts, "/")
 }
 
+func ModifyVaultMetadataPath(path string) string {
+	if strings.Contains(path, "/metadata/") {
+		return path
+	}
+
+	pathParts := strings.Split(strings.Replace(path, "/data/", "/", 1), "/")
+	pathParts = append(pathParts[:1], append([]string{"metadata"}, pathParts[1:]...)...)
+	return strings.Join(pathParts, "/")
+}
+
 func (s *Service) ReadRaw(path string) (*hashiVault.Secret, error) {
 	return s.l.Read(path)
 }
@@ -116,3 +132,34 @@ func (s *Service) GetPropertyFromVault(path string, property string) string {
 
 	return str
 }
+
+// DeleteAll removes secret at path with all its versions and every nested secret under it
+func (s *Service) DeleteAll(path string) error {
+	metadataPath := strings.TrimSuffix(ModifyVaultMetadataPath(path), "/")
+
+	list, err := s.l.List(metadataPath)
+	if err != nil {
+		return fmt.Errorf("unable to list secrets, %w", err)
+	}
+
+	if list != nil {
+		if keys, ok := list.Data["keys"].([]interface{}); ok {
+			for _, k := range keys {
+				key, ok := k.(string)
+				if !ok {
+					continue
+				}
+
+				if err := s.DeleteAll(fmt.Sprintf("%s/%s", metadataPath, strings.TrimSuffix(key, "/"))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if _, err := s.l.Delete(metadataPath); err != nil {
+		return fmt.Errorf("unable to delete secret metadata, %w", err)
+	}
+
+	return nil
+}
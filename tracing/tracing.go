@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+
+	tracerName = "ddm-admin-console"
+)
+
+type Config struct {
+	// Exporter is one of ExporterNone, ExporterOTLP or ExporterStdout,
+	// otlp exporter is configured with standard OTEL_EXPORTER_OTLP_* env variables
+	Exporter    string
+	ServiceName string
+	Version     string
+	SampleRatio float64
+}
+
+// Init sets global tracer provider and propagators, returned function flushes and stops exporter.
+// Without exporter spans are not recorded, but trace context of incoming requests is still propagated
+func Init(ctx context.Context, cnf Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+
+	if cnf.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := makeExporter(ctx, cnf.Exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(cnf.ServiceName),
+		semconv.ServiceVersionKey.String(cnf.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tracing resource, %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cnf.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func makeExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create otlp exporter, %w", err)
+		}
+
+		return exporter, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("unable to create stdout exporter, %w", err)
+		}
+
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", name)
+	}
+}
+
+// GinMiddleware starts span of each request except static files
+func GinMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !strings.HasPrefix(r.URL.Path, "/static/") && !strings.HasPrefix(r.URL.Path, "/assets/")
+	}))
+}
+
+// Start starts child span of span in context, span of gin request is taken from its http request
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if gc, ok := ctx.(*gin.Context); ok && gc.Request != nil {
+		ctx = gc.Request.Context()
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records error of traced operation and ends span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Detach returns background context which carries only span of ctx, so operations which must not
+// be canceled with request are still traced as part of it
+func Detach(ctx context.Context) context.Context {
+	if gc, ok := ctx.(*gin.Context); ok && gc.Request != nil {
+		ctx = gc.Request.Context()
+	}
+
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+}
+
+// Transport wraps client transport with span of each outgoing request and trace context headers
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return otelhttp.NewTransport(rt)
+}
+
+// ZapFields returns trace and span ids of context for log entries
+func ZapFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(Detach(ctx))
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String())}
+}
+
+// KeysAndValues returns trace and span ids of context for sugared log entries
+func KeysAndValues(ctx context.Context) []interface{} {
+	sc := trace.SpanContextFromContext(Detach(ctx))
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []interface{}{"trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String()}
+}
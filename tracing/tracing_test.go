@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	require.Empty(t, ZapFields(context.Background()))
+
+	reqCtx, reqSpan := Start(context.Background(), "request")
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	gc.Request = httptest.NewRequest("GET", "/", nil).WithContext(reqCtx)
+
+	stepCtx, stepSpan := Start(gc, "step")
+	require.Equal(t, reqSpan.SpanContext().TraceID(), stepSpan.SpanContext().TraceID(), "span of gin request is parent")
+	require.Len(t, ZapFields(stepCtx), 2)
+
+	cancelCtx, cancel := context.WithCancel(stepCtx)
+	detached := Detach(cancelCtx)
+	cancel()
+	require.NoError(t, detached.Err())
+	require.Equal(t, stepSpan.SpanContext(), trace.SpanContextFromContext(detached))
+
+	End(stepSpan, errors.New("fatal"))
+	End(reqSpan, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "step", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, reqSpan.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := Init(context.Background(), Config{Exporter: "jaeger"})
+	require.Error(t, err)
+}